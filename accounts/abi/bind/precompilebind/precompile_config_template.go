@@ -90,4 +90,16 @@ func (c *Config) Equal(s precompileconfig.Config) bool {
 	equals := c.Upgrade.Equal(&other.Upgrade) {{- if .Contract.AllowList}} && c.AllowListConfig.Equal(&other.AllowListConfig) {{end}}
 	return equals
 }
+
+// Copy returns a deep copy of [c].
+func (c *Config) Copy() precompileconfig.Config {
+	// CUSTOM CODE STARTS HERE
+	// deep copy any reference types (slices, maps, pointers) added to your custom Config
+	return &Config{
+		{{- if .Contract.AllowList}}
+		AllowListConfig: c.AllowListConfig.Copy(),
+		{{- end}}
+		Upgrade: c.Upgrade.Copy(),
+	}
+}
 `
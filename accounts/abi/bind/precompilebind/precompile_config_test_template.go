@@ -104,4 +104,17 @@ func TestEqual(t *testing.T) {
 		testutils.RunEqualTests(t, tests)
 		{{- end}}
 	}
+
+// TestConfigRoundTrip tests that Config is unchanged after being marshalled to JSON and loaded back.
+func TestConfigRoundTrip(t *testing.T) {
+	{{- if .Contract.AllowList}}
+	admins := []common.Address{allowlist.TestAdminAddr}
+	enableds := []common.Address{allowlist.TestEnabledAddr}
+	managers := []common.Address{allowlist.TestManagerAddr}
+	{{- end}}
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewConfig(utils.NewUint64(3){{- if .Contract.AllowList}}, admins, enableds, managers{{- end}}))
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewDisableConfig(utils.NewUint64(4)))
+	// CUSTOM CODE STARTS HERE
+	// Add round trips of configs with your custom fields set here
+}
 `
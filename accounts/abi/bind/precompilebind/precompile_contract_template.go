@@ -71,18 +71,24 @@ var (
 	_ = common.Big0
 )
 
+// Derive the storage keys of your contract with contract.StorageKey, using a different prefix for each kind
+// of data, and encode stored values with the contract word helpers such as contract.AddressToWord.
+{{- if .Contract.AllowList}}
+// The allow list stores roles under the keys with prefix 0, so your keys must use a non-zero prefix.
+{{- end}}
+
 // Singleton StatefulPrecompiledContract and signatures.
 var (
 	{{- range .Contract.Funcs}}
 
 	{{- if not .Original.IsConstant | and $contract.AllowList}}
 
-	ErrCannot{{.Normalized.Name}} = errors.New("non-enabled cannot call {{.Original.Name}}")
+	ErrCannot{{.Normalized.Name}} = contract.NewCallerNotAllowedError("non-enabled cannot call {{.Original.Name}}")
 	{{- end}}
 	{{- end}}
 
 	{{- if .Contract.Fallback | and $contract.AllowList}}
-	Err{{.Contract.Type}}CannotFallback = errors.New("non-enabled cannot call fallback function")
+	Err{{.Contract.Type}}CannotFallback = contract.NewCallerNotAllowedError("non-enabled cannot call fallback function")
 	{{- end}}
 
 	// {{.Contract.Type}}RawABI contains the raw ABI of {{.Contract.Type}} contract.
@@ -6,9 +6,12 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -16,24 +19,44 @@ import (
 const Version = "v0.1.1"
 
 const (
-	ConfigFilePathKey = "config-file"
-	LogLevelKey       = "log-level"
-	EndpointsKey      = "endpoints"
-	MaxFeeCapKey      = "max-fee-cap"
-	MaxTipCapKey      = "max-tip-cap"
-	WorkersKey        = "workers"
-	TxsPerWorkerKey   = "txs-per-worker"
-	KeyDirKey         = "key-dir"
-	VersionKey        = "version"
-	TimeoutKey        = "timeout"
-	BatchSizeKey      = "batch-size"
-	MetricsPortKey    = "metrics-port"
+	ConfigFilePathKey   = "config-file"
+	LogLevelKey         = "log-level"
+	EndpointsKey        = "endpoints"
+	MaxFeeCapKey        = "max-fee-cap"
+	MaxTipCapKey        = "max-tip-cap"
+	WorkersKey          = "workers"
+	TxsPerWorkerKey     = "txs-per-worker"
+	KeyDirKey           = "key-dir"
+	VersionKey          = "version"
+	TimeoutKey          = "timeout"
+	BatchSizeKey        = "batch-size"
+	MetricsPortKey      = "metrics-port"
+	MetricsAddrKey      = "metrics-addr"
+	TxGasLimitKey       = "tx-gas-limit"
+	TxValueKey          = "tx-value"
+	ContractBytecodeKey = "contract-bytecode"
+	CallDataKey         = "call-data"
+	RandomCallArgKey    = "random-call-arg"
+	StatsFileKey        = "stats-file"
+	AdaptiveFeesKey     = "adaptive-fees"
+	DialAttemptsKey     = "dial-attempts"
+	DialBackoffKey      = "dial-backoff"
+	CaptureFileKey      = "capture-file"
+	ReplayFileKey       = "replay-file"
+	RampUpDurationKey   = "ramp-up-duration"
+	KeystoreDirKey      = "keystore-dir"
+	KeystorePassKey     = "keystore-passphrase"
+	TargetTPSKey        = "target-tps"
+	IssueAttemptsKey    = "issue-attempts"
+	IssueBackoffKey     = "issue-backoff"
 )
 
 var (
 	ErrNoEndpoints = errors.New("must specify at least one endpoint")
 	ErrNoWorkers   = errors.New("must specify non-zero number of workers")
 	ErrNoTxs       = errors.New("must specify non-zero number of txs-per-worker")
+	ErrNoCallData  = errors.New("must specify call-data with at least a 4 byte selector when contract-bytecode is set")
+	ErrBothTxFiles = errors.New("cannot specify both capture-file and replay-file")
 )
 
 type Config struct {
@@ -46,19 +69,75 @@ type Config struct {
 	Timeout      time.Duration `json:"timeout"`
 	BatchSize    uint64        `json:"batch-size"`
 	MetricsPort  uint64        `json:"metrics-port"`
+	TxGasLimit   uint64        `json:"tx-gas-limit"`
+	TxValue      int64         `json:"tx-value"`
+	// MetricsAddr is the address the metrics server listens on. If empty, it listens on
+	// MetricsPort on all interfaces.
+	MetricsAddr string `json:"metrics-addr"`
+	// ContractBytecode and CallData are 0x-prefixed hex. If ContractBytecode is set, the contract
+	// is deployed once and every transaction calls it with CallData instead of sending a transfer.
+	ContractBytecode string `json:"contract-bytecode"`
+	CallData         string `json:"call-data"`
+	StatsFile        string `json:"stats-file"`
+	AdaptiveFees     bool   `json:"adaptive-fees"`
+	// DialAttempts is the number of times each endpoint is dialed before giving up.
+	// The wait between attempts starts at DialBackoff and doubles after each failure.
+	DialAttempts int           `json:"dial-attempts"`
+	DialBackoff  time.Duration `json:"dial-backoff"`
+	// CaptureFile is a file to write the generated transaction sequences to before they are issued.
+	// ReplayFile is a file of transaction sequences, as written to CaptureFile, to issue instead of
+	// generating new ones. The sequences are matched to workers by sender.
+	CaptureFile string `json:"capture-file"`
+	ReplayFile  string `json:"replay-file"`
+	// RampUpDuration is the time over which the workers are started, evenly spaced.
+	// If zero, all workers start at once.
+	RampUpDuration time.Duration `json:"ramp-up-duration"`
+	// KeystoreDir is an encrypted keystore to sign with instead of the plaintext keys in KeyDir.
+	// Its accounts are unlocked with KeystorePass, which can be set through the environment.
+	KeystoreDir  string `json:"keystore-dir"`
+	KeystorePass string `json:"keystore-passphrase"`
+	// TargetTPS caps the rate at which transactions are issued across all workers.
+	// If zero, each worker issues transactions as fast as it can.
+	TargetTPS float64 `json:"target-tps"`
+	// IssueAttempts is the number of times a transaction rejected because the mempool is full or
+	// the nonce is too high is sent before the worker fails. The wait between attempts starts at
+	// IssueBackoff and doubles after each rejection.
+	IssueAttempts int           `json:"issue-attempts"`
+	IssueBackoff  time.Duration `json:"issue-backoff"`
+	// RandomCallArg appends a random 32 byte argument to CallData in each transaction, so that
+	// every contract call is distinct.
+	RandomCallArg bool `json:"random-call-arg"`
 }
 
 func BuildConfig(v *viper.Viper) (Config, error) {
 	c := Config{
-		Endpoints:    v.GetStringSlice(EndpointsKey),
-		MaxFeeCap:    v.GetInt64(MaxFeeCapKey),
-		MaxTipCap:    v.GetInt64(MaxTipCapKey),
-		Workers:      v.GetInt(WorkersKey),
-		TxsPerWorker: v.GetUint64(TxsPerWorkerKey),
-		KeyDir:       v.GetString(KeyDirKey),
-		Timeout:      v.GetDuration(TimeoutKey),
-		BatchSize:    v.GetUint64(BatchSizeKey),
-		MetricsPort:  v.GetUint64(MetricsPortKey),
+		Endpoints:        v.GetStringSlice(EndpointsKey),
+		MaxFeeCap:        v.GetInt64(MaxFeeCapKey),
+		MaxTipCap:        v.GetInt64(MaxTipCapKey),
+		Workers:          v.GetInt(WorkersKey),
+		TxsPerWorker:     v.GetUint64(TxsPerWorkerKey),
+		KeyDir:           v.GetString(KeyDirKey),
+		Timeout:          v.GetDuration(TimeoutKey),
+		BatchSize:        v.GetUint64(BatchSizeKey),
+		MetricsPort:      v.GetUint64(MetricsPortKey),
+		MetricsAddr:      v.GetString(MetricsAddrKey),
+		TxGasLimit:       v.GetUint64(TxGasLimitKey),
+		TxValue:          v.GetInt64(TxValueKey),
+		ContractBytecode: v.GetString(ContractBytecodeKey),
+		CallData:         v.GetString(CallDataKey),
+		RandomCallArg:    v.GetBool(RandomCallArgKey),
+		StatsFile:        v.GetString(StatsFileKey),
+		AdaptiveFees:     v.GetBool(AdaptiveFeesKey),
+		DialAttempts:     v.GetInt(DialAttemptsKey),
+		DialBackoff:      v.GetDuration(DialBackoffKey),
+		CaptureFile:      v.GetString(CaptureFileKey),
+		ReplayFile:       v.GetString(ReplayFileKey),
+		RampUpDuration:   v.GetDuration(RampUpDurationKey),
+		KeystoreDir:      v.GetString(KeystoreDirKey),
+		KeystorePass:     v.GetString(KeystorePassKey),
+		TargetTPS:        v.GetFloat64(TargetTPSKey),
+		IssueAttempts:    v.GetInt(IssueAttemptsKey),
+		IssueBackoff:     v.GetDuration(IssueBackoffKey),
 	}
 	if len(c.Endpoints) == 0 {
 		return c, ErrNoEndpoints
@@ -77,6 +156,50 @@ func BuildConfig(v *viper.Viper) (Config, error) {
 	if c.MaxTipCap < 0 {
 		return c, fmt.Errorf("invalid max tip cap %d <= 0", c.MaxTipCap)
 	}
+	if c.TxGasLimit < params.TxGas {
+		return c, fmt.Errorf("invalid tx gas limit %d < %d", c.TxGasLimit, params.TxGas)
+	}
+	if c.TxValue < 0 {
+		return c, fmt.Errorf("invalid tx value %d < 0", c.TxValue)
+	}
+	if c.DialAttempts < 1 {
+		return c, fmt.Errorf("invalid dial attempts %d < 1", c.DialAttempts)
+	}
+	if c.DialBackoff < 0 {
+		return c, fmt.Errorf("invalid dial backoff %s < 0", c.DialBackoff)
+	}
+	if c.IssueAttempts < 1 {
+		return c, fmt.Errorf("invalid issue attempts %d < 1", c.IssueAttempts)
+	}
+	if c.IssueBackoff < 0 {
+		return c, fmt.Errorf("invalid issue backoff %s < 0", c.IssueBackoff)
+	}
+	if c.TargetTPS < 0 {
+		return c, fmt.Errorf("invalid target tps %f < 0", c.TargetTPS)
+	}
+	if c.RampUpDuration < 0 {
+		return c, fmt.Errorf("invalid ramp up duration %s < 0", c.RampUpDuration)
+	}
+	if len(c.MetricsAddr) != 0 {
+		if _, _, err := net.SplitHostPort(c.MetricsAddr); err != nil {
+			return c, fmt.Errorf("invalid metrics addr: %w", err)
+		}
+	}
+	if len(c.CaptureFile) != 0 && len(c.ReplayFile) != 0 {
+		return c, ErrBothTxFiles
+	}
+	if len(c.ContractBytecode) != 0 {
+		if _, err := hexutil.Decode(c.ContractBytecode); err != nil {
+			return c, fmt.Errorf("invalid contract bytecode: %w", err)
+		}
+		callData, err := hexutil.Decode(c.CallData)
+		if err != nil {
+			return c, fmt.Errorf("invalid call data: %w", err)
+		}
+		if len(callData) < 4 {
+			return c, ErrNoCallData
+		}
+	}
 	return c, nil
 }
 
@@ -122,4 +245,22 @@ func addSimulatorFlags(fs *pflag.FlagSet) {
 	fs.String(LogLevelKey, "info", "Specify the log level to use in the simulator")
 	fs.Uint64(BatchSizeKey, 100, "Specify the batchsize for the worker to issue and confirm txs")
 	fs.Uint64(MetricsPortKey, 8082, "Specify the port to use for the metrics server")
+	fs.String(MetricsAddrKey, "", "Specify the host:port for the metrics server to listen on, overriding metrics-port (e.g. 0.0.0.0:8082 to be scraped remotely)")
+	fs.Uint64(TxGasLimitKey, params.TxGas, "Specify the gas limit to use for each transaction (must be >= 21000)")
+	fs.Int64(TxValueKey, 0, "Specify the value in wei to transfer in each transaction (must be >= 0)")
+	fs.String(ContractBytecodeKey, "", "Specify 0x-prefixed contract bytecode to deploy once and call in every transaction instead of sending transfers")
+	fs.String(CallDataKey, "", "Specify 0x-prefixed call data, starting with the 4 byte selector, to call the deployed contract with")
+	fs.Bool(RandomCallArgKey, false, "Append a random 32 byte argument to call-data in each transaction, so that every contract call is distinct")
+	fs.String(StatsFileKey, "", "Specify a file to write the per worker stats to as JSON at the end of the simulation")
+	fs.Int(DialAttemptsKey, 5, "Specify the number of times to dial each endpoint before failing (must be >= 1)")
+	fs.Duration(DialBackoffKey, time.Second, "Specify the wait before retrying to dial an endpoint, doubled after each failed attempt")
+	fs.String(CaptureFileKey, "", "Specify a file to write the generated transactions to as JSON before issuing them, so the run can be replayed. The file is gzip compressed if its name ends in .gz")
+	fs.String(ReplayFileKey, "", "Specify a file of transactions written by capture-file to issue instead of generating new ones (the senders' nonces must match those at capture). The file is decompressed if its name ends in .gz")
+	fs.Duration(RampUpDurationKey, 0, "Specify the duration over which to start the workers gradually instead of all at once (0 starts all workers immediately)")
+	fs.String(KeystoreDirKey, "", "Specify an encrypted keystore directory to sign with instead of the plaintext keys in key-dir (missing accounts are created)")
+	fs.String(KeystorePassKey, "", "Specify the passphrase of the keystore accounts (prefer setting EVM_SIMULATOR_KEYSTORE_PASSPHRASE)")
+	fs.Int(IssueAttemptsKey, 5, "Specify the number of times to send a transaction rejected because the mempool is congested before failing (must be >= 1)")
+	fs.Duration(IssueBackoffKey, time.Second, "Specify the wait before resending a transaction rejected because the mempool is congested, doubled after each rejection")
+	fs.Float64(TargetTPSKey, 0, "Specify the maximum number of transactions per second to issue across all workers (0 indicates no limit)")
+	fs.Bool(AdaptiveFeesKey, false, "Raise the fee cap up to 4 times max-fee-cap to follow the chain's base fee under congestion (accounts are funded for the raised fee cap)")
 }
@@ -6,6 +6,7 @@ package key
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/binary"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -83,3 +84,24 @@ func Generate() (*Key, error) {
 	}
 	return createKey(pk), nil
 }
+
+// GenerateDeterministicKeys returns [n] private keys derived from [seed], so the same seed always yields
+// the same keys and addresses. This lets the funded addresses of a test genesis be known ahead of a run.
+//
+// These keys are for local testing only: anyone who knows or guesses [seed] can derive them, so they must
+// never hold funds on a public network.
+func GenerateDeterministicKeys(seed []byte, n int) []*ecdsa.PrivateKey {
+	pks := make([]*ecdsa.PrivateKey, 0, n)
+	var counter [8]byte
+	for i := uint64(0); len(pks) < n; i++ {
+		binary.BigEndian.PutUint64(counter[:], i)
+		// A hash is not a valid key if it is zero or not less than the curve order. This is
+		// vanishingly unlikely, but skipping such a hash keeps the derivation deterministic.
+		pk, err := ethcrypto.ToECDSA(ethcrypto.Keccak256(seed, counter[:]))
+		if err != nil {
+			continue
+		}
+		pks = append(pks, pk)
+	}
+	return pks
+}
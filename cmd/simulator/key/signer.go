@@ -0,0 +1,82 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts"
+	"github.com/ava-labs/subnet-evm/accounts/keystore"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ Signer = (*keySigner)(nil)
+	_ Signer = (*keystoreSigner)(nil)
+)
+
+// Signer signs transactions on behalf of a single address. Implementations do not need to expose
+// the private key of the address, so it can be held by an encrypted keystore or a remote signer.
+type Signer interface {
+	// Address returns the address transactions are signed for.
+	Address() common.Address
+	// SignTx returns [tx] signed for [chainID].
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// keySigner signs with a [Key] held in memory.
+type keySigner struct {
+	key *Key
+}
+
+// Signer returns a Signer that signs with [k].
+func (k *Key) Signer() Signer {
+	return &keySigner{key: k}
+}
+
+func (s *keySigner) Address() common.Address {
+	return s.key.Address
+}
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key.PrivKey)
+}
+
+// keystoreSigner signs with an unlocked account of an encrypted keystore.
+type keystoreSigner struct {
+	keystore *keystore.KeyStore
+	account  accounts.Account
+}
+
+func (s *keystoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *keystoreSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.keystore.SignTx(s.account, tx, chainID)
+}
+
+// OpenKeystore opens the encrypted keystore in [dir], creating accounts encrypted with [passphrase] until
+// it has at least [minAccounts], and returns a Signer for each of its accounts.
+// Every account is unlocked with [passphrase], so the private keys stay within the keystore.
+func OpenKeystore(dir string, passphrase string, minAccounts int) ([]Signer, error) {
+	ks := keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP)
+	for i := len(ks.Accounts()); i < minAccounts; i++ {
+		if _, err := ks.NewAccount(passphrase); err != nil {
+			return nil, fmt.Errorf("failed to create keystore account %d: %w", i, err)
+		}
+	}
+
+	keystoreAccounts := ks.Accounts()
+	signers := make([]Signer, 0, len(keystoreAccounts))
+	for _, account := range keystoreAccounts {
+		if err := ks.Unlock(account, passphrase); err != nil {
+			return nil, fmt.Errorf("failed to unlock keystore account %s: %w", account.Address, err)
+		}
+		signers = append(signers, &keystoreSigner{keystore: ks, account: account})
+	}
+	return signers, nil
+}
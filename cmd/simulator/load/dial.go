@@ -0,0 +1,38 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// DialWithRetry dials [uri] up to [attempts] times, so that the simulator can start before a freshly
+// launched network is serving RPC requests. The wait between attempts starts at [backoff] and doubles
+// after each failure. Returns ctx.Err() if [ctx] is done while waiting to retry.
+func DialWithRetry(ctx context.Context, uri string, attempts int, backoff time.Duration) (ethclient.Client, error) {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			log.Info("Retrying to dial client", "uri", uri, "attempt", i+1, "backoff", backoff, "err", err)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var client ethclient.Client
+		client, err = ethclient.DialContext(ctx, uri)
+		if err == nil {
+			return client, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to dial client at %s after %d attempts: %w", uri, attempts, err)
+}
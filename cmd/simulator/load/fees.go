@@ -0,0 +1,101 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// adaptiveFeeUpdateInterval is how often the adaptive fee cap is recomputed from the chain's base fee.
+	adaptiveFeeUpdateInterval = 5 * time.Second
+	// adaptiveFeeCapHeadroom is the multiple of the configured fee cap that the adaptive fee cap may rise to.
+	adaptiveFeeCapHeadroom = 4
+)
+
+// adaptiveFeeCap tracks a fee cap that follows the base fee of the latest block, so that
+// transactions generated late in a long simulation are not underpriced under congestion.
+type adaptiveFeeCap struct {
+	ctx       context.Context
+	client    ethclient.Client
+	gasTipCap *big.Int
+	minFeeCap *big.Int
+	maxFeeCap *big.Int
+
+	lock       sync.Mutex
+	feeCap     *big.Int
+	lastUpdate time.Time
+}
+
+func newAdaptiveFeeCap(ctx context.Context, client ethclient.Client, gasTipCap *big.Int, minFeeCap *big.Int, maxFeeCap *big.Int) *adaptiveFeeCap {
+	return &adaptiveFeeCap{
+		ctx:       ctx,
+		client:    client,
+		gasTipCap: gasTipCap,
+		minFeeCap: minFeeCap,
+		maxFeeCap: maxFeeCap,
+		feeCap:    minFeeCap,
+	}
+}
+
+// GasFeeCap returns the larger of the configured fee cap and twice the latest base fee plus the tip,
+// limited to the fee cap the senders were funded for and refreshing the base fee at most once per
+// [adaptiveFeeUpdateInterval]. If the base fee cannot be fetched, the previous fee cap is returned.
+func (a *adaptiveFeeCap) GasFeeCap() *big.Int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if time.Since(a.lastUpdate) < adaptiveFeeUpdateInterval {
+		return a.feeCap
+	}
+	a.lastUpdate = time.Now()
+
+	header, err := a.client.HeaderByNumber(a.ctx, nil)
+	if err != nil {
+		log.Warn("Failed to fetch latest header for adaptive fees", "err", err)
+		return a.feeCap
+	}
+	if header.BaseFee == nil {
+		return a.feeCap
+	}
+
+	feeCap := new(big.Int).Lsh(header.BaseFee, 1)
+	feeCap.Add(feeCap, a.gasTipCap)
+	if feeCap.Cmp(a.minFeeCap) < 0 {
+		feeCap = a.minFeeCap
+	}
+	if feeCap.Cmp(a.maxFeeCap) > 0 {
+		log.Warn("Base fee exceeds funded gas fee cap", "baseFee", header.BaseFee, "maxGasFeeCap", a.maxFeeCap)
+		feeCap = a.maxFeeCap
+	}
+	if feeCap.Cmp(a.feeCap) != 0 {
+		log.Info("Adjusted gas fee cap", "baseFee", header.BaseFee, "gasFeeCap", feeCap)
+	}
+	a.feeCap = feeCap
+	return a.feeCap
+}
+
+// newGasFeeCapFunc returns the fee cap to use for each generated transaction. The fee cap is fixed
+// to [gasFeeCap] unless [adaptive] is set, in which case it follows the chain's base fee.
+func newGasFeeCapFunc(ctx context.Context, client ethclient.Client, adaptive bool, gasTipCap *big.Int, gasFeeCap *big.Int) func() *big.Int {
+	if !adaptive {
+		return func() *big.Int { return gasFeeCap }
+	}
+	return newAdaptiveFeeCap(ctx, client, gasTipCap, gasFeeCap, maxGasFeeCap(adaptive, gasFeeCap)).GasFeeCap
+}
+
+// maxGasFeeCap returns the highest fee cap of the transactions generated with the fee cap [gasFeeCap],
+// which senders must be funded for.
+func maxGasFeeCap(adaptive bool, gasFeeCap *big.Int) *big.Int {
+	if !adaptive {
+		return gasFeeCap
+	}
+	return new(big.Int).Mul(gasFeeCap, big.NewInt(adaptiveFeeCapHeadroom))
+}
@@ -5,7 +5,6 @@ package load
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
@@ -19,30 +18,38 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-// DistributeFunds ensures that each address in keys has at least [minFundsPerAddr] by sending funds
+// DistributeFunds ensures that the address of each of [keys] has at least [minFundsPerAddr] by sending funds
 // from the key with the highest starting balance.
 // This function returns a set of at least [numKeys] keys, each having a minimum balance [minFundsPerAddr].
-func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.Key, numKeys int, minFundsPerAddr *big.Int, m *metrics.Metrics) ([]*key.Key, error) {
+// The first returned key has at least [firstKeyExtraFunds] more, to pay for transactions sent before the load,
+// such as a contract deployment.
+func DistributeFunds(ctx context.Context, client ethclient.Client, keys []key.Signer, numKeys int, minFundsPerAddr *big.Int, firstKeyExtraFunds *big.Int, m *metrics.Metrics) ([]key.Signer, error) {
 	if len(keys) < numKeys {
 		return nil, fmt.Errorf("insufficient number of keys %d < %d", len(keys), numKeys)
 	}
-	fundedKeys := make([]*key.Key, 0, numKeys)
+	fundedKeys := make([]key.Signer, 0, numKeys)
 	// TODO: clean up fund distribution.
-	needFundsKeys := make([]*key.Key, 0)
+	needFundsKeys := make([]key.Signer, 0)
 	needFundsAddrs := make([]common.Address, 0)
 
 	maxFundsKey := keys[0]
 	maxFundsBalance := common.Big0
+	firstKeyMinFunds := new(big.Int).Add(minFundsPerAddr, firstKeyExtraFunds)
 	log.Info("Checking balance of each key to distribute funds")
 	for _, key := range keys {
-		balance, err := client.BalanceAt(ctx, key.Address, nil)
+		balance, err := client.BalanceAt(ctx, key.Address(), nil)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch balance for addr %s: %w", key.Address, err)
+			return nil, fmt.Errorf("failed to fetch balance for addr %s: %w", key.Address(), err)
 		}
 
-		if balance.Cmp(minFundsPerAddr) < 0 {
+		// The first funded key is returned first, so it must also hold [firstKeyExtraFunds].
+		keyMinFunds := minFundsPerAddr
+		if len(fundedKeys) == 0 {
+			keyMinFunds = firstKeyMinFunds
+		}
+		if balance.Cmp(keyMinFunds) < 0 {
 			needFundsKeys = append(needFundsKeys, key)
-			needFundsAddrs = append(needFundsAddrs, key.Address)
+			needFundsAddrs = append(needFundsAddrs, key.Address())
 		} else {
 			fundedKeys = append(fundedKeys, key)
 		}
@@ -53,10 +60,15 @@ func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.K
 		}
 	}
 	requiredFunds := new(big.Int).Mul(minFundsPerAddr, big.NewInt(int64(numKeys)))
-	if maxFundsBalance.Cmp(requiredFunds) < 0 {
-		return nil, fmt.Errorf("insufficient funds to distribute %d < %d", maxFundsBalance, requiredFunds)
+	// If no key is funded yet, the first key to be funded is returned first and is sent [firstKeyExtraFunds] as well.
+	firstKeyFunds := requiredFunds
+	if len(fundedKeys) == 0 {
+		firstKeyFunds = new(big.Int).Add(requiredFunds, firstKeyExtraFunds)
+	}
+	if totalFunds := new(big.Int).Add(requiredFunds, firstKeyExtraFunds); maxFundsBalance.Cmp(totalFunds) < 0 {
+		return nil, fmt.Errorf("insufficient funds to distribute %d < %d", maxFundsBalance, totalFunds)
 	}
-	log.Info("Found max funded key", "address", maxFundsKey.Address, "balance", maxFundsBalance, "numFundAddrs", len(needFundsAddrs))
+	log.Info("Found max funded key", "address", maxFundsKey.Address(), "balance", maxFundsBalance, "numFundAddrs", len(needFundsAddrs))
 	if len(fundedKeys) >= numKeys {
 		return fundedKeys[:numKeys], nil
 	}
@@ -79,13 +91,15 @@ func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.K
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch suggested gas tip: %w", err)
 	}
-	signer := types.LatestSignerForChainID(chainID)
-
 	// Generate a sequence of transactions to distribute the required funds.
 	log.Info("Generating distribution transactions...")
 	i := 0
-	txGenerator := func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
-		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+	txGenerator := func(signer key.Signer, nonce uint64) (*types.Transaction, error) {
+		value := requiredFunds
+		if i == 0 {
+			value = firstKeyFunds
+		}
+		tx, err := signer.SignTx(types.NewTx(&types.DynamicFeeTx{
 			ChainID:   chainID,
 			Nonce:     nonce,
 			GasTipCap: gasTipCap,
@@ -93,8 +107,8 @@ func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.K
 			Gas:       params.TxGas,
 			To:        &needFundsAddrs[i],
 			Data:      nil,
-			Value:     requiredFunds,
-		})
+			Value:     value,
+		}), chainID)
 		if err != nil {
 			return nil, err
 		}
@@ -103,12 +117,14 @@ func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.K
 	}
 
 	numTxs := uint64(len(needFundsAddrs))
-	txSequence, err := txs.GenerateTxSequence(ctx, txGenerator, client, maxFundsKey.PrivKey, numTxs)
+	txSequence, err := txs.GenerateTxSequence(ctx, txGenerator, client, maxFundsKey, numTxs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate fund distribution sequence from %s of length %d", maxFundsKey.Address, len(needFundsAddrs))
+		return nil, fmt.Errorf("failed to generate fund distribution sequence from %s of length %d", maxFundsKey.Address(), len(needFundsAddrs))
 	}
-	worker := NewSingleAddressTxWorker(ctx, client, maxFundsKey.Address)
-	txFunderAgent := txs.NewIssueNAgent[*types.Transaction](txSequence, worker, numTxs, m)
+	// Funding issues few transactions, so they are not retried if the mempool is congested.
+	worker := NewSingleAddressTxWorker(ctx, client, maxFundsKey.Address(), 1, 0)
+	// Use an index outside of the load workers' range so funding does not count towards their stats.
+	txFunderAgent := txs.NewIssueNAgent[*types.Transaction](txSequence, worker, numTxs, m, -1)
 
 	if err := txFunderAgent.Execute(ctx); err != nil {
 		return nil, err
@@ -5,17 +5,19 @@ package load
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
 	"github.com/ava-labs/subnet-evm/cmd/simulator/key"
@@ -25,15 +27,16 @@ import (
 	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ethereum/go-ethereum/common"
-	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 )
 
 const (
-	MetricsEndpoint = "/metrics" // Endpoint for the Prometheus Metrics Server
+	MetricsEndpoint  = "/metrics"       // Endpoint for the Prometheus Metrics Server
+	reconcileTimeout = 30 * time.Second // Timeout to fetch the final nonces of the workers after a run
 )
 
 // ExecuteLoader creates txSequences from [config] and has txAgents execute the specified simulation.
@@ -62,123 +65,259 @@ func ExecuteLoader(ctx context.Context, config config.Config) error {
 		cancel()
 	}()
 
-	// Construct the arguments for the load simulator
+	// Construct the arguments for the load simulator.
+	// Dial each endpoint once. Workers are assigned to the clients round-robin.
 	clients := make([]ethclient.Client, 0, len(config.Endpoints))
-	for i := 0; i < config.Workers; i++ {
-		clientURI := config.Endpoints[i%len(config.Endpoints)]
-		client, err := ethclient.Dial(clientURI)
+	for _, clientURI := range config.Endpoints {
+		client, err := DialWithRetry(ctx, clientURI, config.DialAttempts, config.DialBackoff)
 		if err != nil {
-			return fmt.Errorf("failed to dial client at %s: %w", clientURI, err)
+			return err
 		}
 		clients = append(clients, client)
 	}
 
-	keys, err := key.LoadAll(ctx, config.KeyDir)
+	keys, err := loadKeys(ctx, config)
 	if err != nil {
 		return err
 	}
-	// Ensure there are at least [config.Workers] keys and save any newly generated ones.
-	if len(keys) < config.Workers {
-		for i := 0; len(keys) < config.Workers; i++ {
-			newKey, err := key.Generate()
-			if err != nil {
-				return fmt.Errorf("failed to generate %d new key: %w", i, err)
-			}
-			if err := newKey.Save(config.KeyDir); err != nil {
-				return fmt.Errorf("failed to save %d new key: %w", i, err)
-			}
-			keys = append(keys, newKey)
+
+	// Each address needs: (params.GWei * MaxFeeCap * TxGasLimit + TxValue) * TxsPerWorker total wei
+	// to fund gas and value for all of their transactions. With adaptive fees, the fee cap may rise
+	// above MaxFeeCap, so the funds cover the highest fee cap it may reach.
+	maxFeeCap := maxGasFeeCap(config.AdaptiveFees, new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(config.MaxFeeCap)))
+	maxCostPerTx := new(big.Int).Mul(maxFeeCap, new(big.Int).SetUint64(config.TxGasLimit))
+	maxCostPerTx.Add(maxCostPerTx, big.NewInt(config.TxValue))
+	minFundsPerAddr := new(big.Int).Mul(maxCostPerTx, new(big.Int).SetUint64(config.TxsPerWorker))
+	// The contract to call is deployed from the first funded key, which must also pay for the deployment.
+	deploymentFunds := new(big.Int)
+	if len(config.ReplayFile) == 0 && len(config.ContractBytecode) != 0 {
+		deploymentFunds, err = estimateDeploymentCost(ctx, clients[0], maxFeeCap, common.FromHex(config.ContractBytecode))
+		if err != nil {
+			return err
 		}
 	}
 
-	// Each address needs: params.GWei * MaxFeeCap * params.TxGas * TxsPerWorker total wei
-	// to fund gas for all of their transactions.
-	maxFeeCap := new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(config.MaxFeeCap))
-	minFundsPerAddr := new(big.Int).Mul(maxFeeCap, big.NewInt(int64(config.TxsPerWorker*params.TxGas)))
-
 	// Create metrics
 	reg := prometheus.NewRegistry()
 	m := metrics.NewMetrics(reg)
-	metricsPort := strconv.Itoa(int(config.MetricsPort))
+	metricsAddr := config.MetricsAddr
+	if len(metricsAddr) == 0 {
+		metricsAddr = ":" + strconv.Itoa(int(config.MetricsPort))
+	}
+	// Start serving metrics before funding the workers, so the whole run can be scraped.
+	go startMetricsServer(ctx, metricsAddr, reg)
 
-	log.Info("Distributing funds", "numTxsPerWorker", config.TxsPerWorker, "minFunds", minFundsPerAddr)
-	keys, err = DistributeFunds(ctx, clients[0], keys, config.Workers, minFundsPerAddr, m)
+	log.Info("Distributing funds", "numTxsPerWorker", config.TxsPerWorker, "minFunds", minFundsPerAddr, "deploymentFunds", deploymentFunds)
+	keys, err = DistributeFunds(ctx, clients[0], keys, config.Workers, minFundsPerAddr, deploymentFunds, m)
 	if err != nil {
 		return err
 	}
 	log.Info("Distributed funds successfully")
 
-	pks := make([]*ecdsa.PrivateKey, 0, len(keys))
 	senders := make([]common.Address, 0, len(keys))
 	for _, key := range keys {
-		pks = append(pks, key.PrivKey)
-		senders = append(senders, key.Address)
+		senders = append(senders, key.Address())
+	}
+	if len(keys) < config.Workers {
+		return fmt.Errorf("insufficient number of funded keys %d < %d workers", len(keys), config.Workers)
 	}
 
-	bigGwei := big.NewInt(params.GWei)
-	gasTipCap := new(big.Int).Mul(bigGwei, big.NewInt(config.MaxTipCap))
-	gasFeeCap := new(big.Int).Mul(bigGwei, big.NewInt(config.MaxFeeCap))
 	client := clients[0]
 	chainID, err := client.ChainID(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch chainID: %w", err)
 	}
-	signer := types.LatestSignerForChainID(chainID)
 
 	log.Info("Creating transaction sequences...")
-	txGenerator := func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
-		addr := ethcrypto.PubkeyToAddress(key.PublicKey)
-		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
-			ChainID:   chainID,
-			Nonce:     nonce,
-			GasTipCap: gasTipCap,
-			GasFeeCap: gasFeeCap,
-			Gas:       params.TxGas,
-			To:        &addr,
-			Data:      nil,
-			Value:     common.Big0,
-		})
-		if err != nil {
-			return nil, err
+	var txSequences []txs.TxSequence[*types.Transaction]
+	switch {
+	case len(config.ReplayFile) != 0:
+		txSequences, err = GetReplayTxSequences(ctx, config.ReplayFile, chainID, senders)
+	case len(config.ContractBytecode) != 0:
+		callDataFn := FixedCallData(common.FromHex(config.CallData))
+		if config.RandomCallArg {
+			callDataFn = RandomArgCallData(common.FromHex(config.CallData))
 		}
-		return tx, nil
+		txSequences, err = GetContractCallTxSequences(ctx, config, chainID, keys, client, callDataFn)
+	default:
+		txSequences, err = GetEVMTxSequences(ctx, config, chainID, keys, client)
 	}
-	txSequences, err := txs.GenerateTxSequences(ctx, txGenerator, clients[0], pks, config.TxsPerWorker)
 	if err != nil {
 		return err
 	}
+	if len(config.CaptureFile) != 0 {
+		txSequences, err = captureTxSequences(config.CaptureFile, txSequences)
+		if err != nil {
+			return err
+		}
+		log.Info("Captured transaction sequences", "file", config.CaptureFile)
+	}
+	if len(txSequences) < config.Workers {
+		return fmt.Errorf("insufficient number of tx sequences %d < %d workers", len(txSequences), config.Workers)
+	}
+	if config.TargetTPS > 0 {
+		// All workers share a single limiter, so the target applies to their combined rate.
+		limiter := rate.NewLimiter(rate.Limit(config.TargetTPS), 1)
+		for i, txSequence := range txSequences {
+			txSequences[i] = txs.RateLimit(ctx, txSequence, limiter)
+		}
+	}
 
 	log.Info("Constructing tx agents...", "numAgents", config.Workers)
 	agents := make([]txs.Agent[*types.Transaction], 0, config.Workers)
+	workers := make([]*singleAddressTxWorker, 0, config.Workers)
 	for i := 0; i < config.Workers; i++ {
-		agents = append(agents, txs.NewIssueNAgent[*types.Transaction](txSequences[i], NewSingleAddressTxWorker(ctx, clients[i], senders[i]), config.BatchSize, m))
+		worker := NewSingleAddressTxWorker(ctx, clients[i%len(clients)], senders[i], config.IssueAttempts, config.IssueBackoff)
+		workers = append(workers, worker)
+		agents = append(agents, txs.NewIssueNAgent[*types.Transaction](txSequences[i], worker, config.BatchSize, m, i))
 	}
 
-	log.Info("Starting tx agents...")
+	log.Info("Starting tx agents...", "rampUpDuration", config.RampUpDuration)
+	// Each agent records its error instead of returning it, so that a failed agent neither stops
+	// the healthy agents nor hides the errors of other failed agents.
+	agentErrs := make([]error, len(agents))
 	eg := errgroup.Group{}
-	for _, agent := range agents {
+	for i, agent := range agents {
+		i := i
 		agent := agent
+		startDelay := rampUpDelay(i, len(agents), config.RampUpDuration)
 		eg.Go(func() error {
-			return agent.Execute(ctx)
+			if startDelay > 0 {
+				timer := time.NewTimer(startDelay)
+				defer timer.Stop()
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					agentErrs[i] = ctx.Err()
+					return nil
+				}
+			}
+			agentErrs[i] = agent.Execute(ctx)
+			return nil
 		})
 	}
 
-	go startMetricsServer(ctx, metricsPort, reg)
-
 	log.Info("Waiting for tx agents...")
-	if err := eg.Wait(); err != nil {
-		return err
+	_ = eg.Wait()
+	// Reconcile even if the agents failed, since that is when submitted transactions are most likely
+	// to have been dropped. [ctx] may have timed out, so reconcile with a separate context.
+	reconcileCtx, reconcileCancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer reconcileCancel()
+	reconcileWorkers(reconcileCtx, workers)
+	agentsErr := joinAgentErrors(agentErrs)
+	if agentsErr == nil {
+		log.Info("Tx agents completed successfully.")
+	}
+
+	printOutputFromMetricsServer(metricsAddr)
+	if err := writeWorkerStats(m, config.Workers, config.StatsFile); err != nil {
+		return errors.Join(agentsErr, err)
+	}
+	return agentsErr
+}
+
+// joinAgentErrors logs each of the non-nil [agentErrs], indexed by worker, and returns an error
+// reporting how many of the agents failed and why. Returns nil if no agent failed.
+func joinAgentErrors(agentErrs []error) error {
+	var errs []error
+	for i, err := range agentErrs {
+		if err == nil {
+			continue
+		}
+		log.Error("Tx agent failed", "worker", i, "err", err)
+		errs = append(errs, fmt.Errorf("worker %d: %w", i, err))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	log.Error("Tx agents failed", "failed", len(errs), "total", len(agentErrs))
+	return fmt.Errorf("%d of %d tx agents failed: %w", len(errs), len(agentErrs), errors.Join(errs...))
+}
+
+// loadKeys returns at least [config.Workers] keys to sign with. Keys are unlocked from the encrypted keystore in
+// [config.KeystoreDir] if it is set, and otherwise loaded from [config.KeyDir]. Missing keys are generated and saved.
+func loadKeys(ctx context.Context, config config.Config) ([]key.Signer, error) {
+	if len(config.KeystoreDir) != 0 {
+		return key.OpenKeystore(config.KeystoreDir, config.KeystorePass, config.Workers)
 	}
-	log.Info("Tx agents completed successfully.")
 
-	printOutputFromMetricsServer(metricsPort)
+	keys, err := key.LoadAll(ctx, config.KeyDir)
+	if err != nil {
+		return nil, err
+	}
+	// Ensure there are at least [config.Workers] keys and save any newly generated ones.
+	for i := 0; len(keys) < config.Workers; i++ {
+		newKey, err := key.Generate()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %d new key: %w", i, err)
+		}
+		if err := newKey.Save(config.KeyDir); err != nil {
+			return nil, fmt.Errorf("failed to save %d new key: %w", i, err)
+		}
+		keys = append(keys, newKey)
+	}
+
+	signers := make([]key.Signer, 0, len(keys))
+	for _, key := range keys {
+		signers = append(signers, key.Signer())
+	}
+	return signers, nil
+}
+
+// rampUpDelay returns the delay before starting agent [i] of [numAgents], so that the agents are started evenly
+// spaced over [rampUpDuration] with the first started immediately.
+func rampUpDelay(i int, numAgents int, rampUpDuration time.Duration) time.Duration {
+	if numAgents == 0 {
+		return 0
+	}
+	return rampUpDuration * time.Duration(i) / time.Duration(numAgents)
+}
+
+// reconcileWorkers logs how many of the transactions submitted by each of [workers] were accepted.
+// Submitted transactions whose nonce was not accepted were dropped or replaced.
+func reconcileWorkers(ctx context.Context, workers []*singleAddressTxWorker) {
+	var totalSubmitted, totalAccepted uint64
+	for i, worker := range workers {
+		submitted, accepted, err := worker.Reconcile(ctx)
+		if err != nil {
+			log.Error("Failed to reconcile worker", "worker", i, "err", err)
+			continue
+		}
+		log.Info("Worker reconciliation", "worker", i, "submitted", submitted, "accepted", accepted, "droppedOrReplaced", submitted-accepted)
+		totalSubmitted += submitted
+		totalAccepted += accepted
+	}
+	log.Info("Reconciliation complete", "submitted", totalSubmitted, "accepted", totalAccepted, "droppedOrReplaced", totalSubmitted-totalAccepted)
+}
+
+// writeWorkerStats logs the per worker stats and writes them as JSON to [statsFile] if it is set.
+func writeWorkerStats(m *metrics.Metrics, numWorkers int, statsFile string) error {
+	workerStats, err := m.WorkerStats(numWorkers)
+	if err != nil {
+		return fmt.Errorf("failed to collect worker stats: %w", err)
+	}
+	for _, stats := range workerStats {
+		log.Info("Worker stats", "worker", stats.Worker, "issued", stats.IssuedTxs, "confirmed", stats.ConfirmedTxs,
+			"latencyP50", stats.LatencyP50, "latencyP90", stats.LatencyP90, "latencyP99", stats.LatencyP99)
+	}
+	if len(statsFile) == 0 {
+		return nil
+	}
+
+	statsBytes, err := json.MarshalIndent(workerStats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal worker stats: %w", err)
+	}
+	if err := os.WriteFile(statsFile, statsBytes, 0o644); err != nil {
+		return fmt.Errorf("failed to write worker stats to %s: %w", statsFile, err)
+	}
 	return nil
 }
 
-func startMetricsServer(ctx context.Context, metricsPort string, reg *prometheus.Registry) {
+func startMetricsServer(ctx context.Context, metricsAddr string, reg *prometheus.Registry) {
 	// Create a prometheus server to expose individual tx metrics
 	server := &http.Server{
-		Addr: fmt.Sprintf(":%s", metricsPort),
+		Addr: metricsAddr,
 	}
 
 	// Start up go routine to listen for SIGINT notifications to gracefully shut down server
@@ -194,15 +333,24 @@ func startMetricsServer(ctx context.Context, metricsPort string, reg *prometheus
 
 	// Start metrics server
 	http.Handle(MetricsEndpoint, promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
-	log.Info(fmt.Sprintf("Metrics Server: localhost:%s%s", metricsPort, MetricsEndpoint))
+	log.Info(fmt.Sprintf("Metrics Server: %s%s", metricsURL(metricsAddr), MetricsEndpoint))
 	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 		log.Error("Metrics server error: %v", err)
 	}
 }
 
-func printOutputFromMetricsServer(metricsPort string) {
+// metricsURL returns the URL to reach the metrics server listening on [metricsAddr] from this host.
+func metricsURL(metricsAddr string) string {
+	host, port, err := net.SplitHostPort(metricsAddr)
+	if err != nil || len(host) == 0 {
+		host = "localhost"
+	}
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host, port))
+}
+
+func printOutputFromMetricsServer(metricsAddr string) {
 	// Get response from server
-	resp, err := http.Get(fmt.Sprintf("http://localhost:%s%s", metricsPort, MetricsEndpoint))
+	resp, err := http.Get(metricsURL(metricsAddr) + MetricsEndpoint)
 	if err != nil {
 		log.Error("cannot get response from metrics servers", "err", err)
 		return
@@ -0,0 +1,224 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/key"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// GetEVMTxSequences returns a sequence of [config.TxsPerWorker] self transfers for each of [signers],
+// starting from each signer's current nonce as reported by [client].
+func GetEVMTxSequences(ctx context.Context, config config.Config, chainID *big.Int, signers []key.Signer, client ethclient.Client) ([]txs.TxSequence[*types.Transaction], error) {
+	bigGwei := big.NewInt(params.GWei)
+	gasTipCap := new(big.Int).Mul(bigGwei, big.NewInt(config.MaxTipCap))
+	gasFeeCap := newGasFeeCapFunc(ctx, client, config.AdaptiveFees, gasTipCap, new(big.Int).Mul(bigGwei, big.NewInt(config.MaxFeeCap)))
+	value := big.NewInt(config.TxValue)
+
+	txGenerator := func(signer key.Signer, nonce uint64) (*types.Transaction, error) {
+		addr := signer.Address()
+		tx, err := signer.SignTx(types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap(),
+			Gas:       config.TxGasLimit,
+			To:        &addr,
+			Data:      nil,
+			Value:     value,
+		}), chainID)
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
+	return txs.GenerateTxSequences(ctx, txGenerator, client, signers, config.TxsPerWorker)
+}
+
+// CallDataFunc returns the call data of the transaction sent by [signer] with [nonce] in contract call mode,
+// so that each call can be distinct.
+type CallDataFunc func(signer key.Signer, nonce uint64) ([]byte, error)
+
+// FixedCallData returns a CallDataFunc that calls the contract with [callData] in every transaction.
+func FixedCallData(callData []byte) CallDataFunc {
+	return func(key.Signer, uint64) ([]byte, error) {
+		return callData, nil
+	}
+}
+
+// RandomArgCallData returns a CallDataFunc that appends a random 32 byte argument to [callData] in every
+// transaction, so calls cannot be deduplicated and each may write different state.
+func RandomArgCallData(callData []byte) CallDataFunc {
+	return func(key.Signer, uint64) ([]byte, error) {
+		data := make([]byte, len(callData)+common.HashLength)
+		copy(data, callData)
+		if _, err := rand.Read(data[len(callData):]); err != nil {
+			return nil, fmt.Errorf("failed to generate random call argument: %w", err)
+		}
+		return data, nil
+	}
+}
+
+// GetContractCallTxSequences deploys [config.ContractBytecode] from the first of [signers] and returns a sequence of
+// [config.TxsPerWorker] calls to the deployed contract for each of [signers], with the call data returned by [callDataFn].
+func GetContractCallTxSequences(ctx context.Context, config config.Config, chainID *big.Int, signers []key.Signer, client ethclient.Client, callDataFn CallDataFunc) ([]txs.TxSequence[*types.Transaction], error) {
+	bigGwei := big.NewInt(params.GWei)
+	gasTipCap := new(big.Int).Mul(bigGwei, big.NewInt(config.MaxTipCap))
+	gasFeeCap := newGasFeeCapFunc(ctx, client, config.AdaptiveFees, gasTipCap, new(big.Int).Mul(bigGwei, big.NewInt(config.MaxFeeCap)))
+	value := big.NewInt(config.TxValue)
+
+	contractAddr, err := deployContract(ctx, client, chainID, signers[0], gasTipCap, gasFeeCap(), common.FromHex(config.ContractBytecode))
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Deployed contract", "address", contractAddr)
+
+	txGenerator := func(signer key.Signer, nonce uint64) (*types.Transaction, error) {
+		callData, err := callDataFn(signer, nonce)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := signer.SignTx(types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap(),
+			Gas:       config.TxGasLimit,
+			To:        &contractAddr,
+			Data:      callData,
+			Value:     value,
+		}), chainID)
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
+	return txs.GenerateTxSequences(ctx, txGenerator, client, signers, config.TxsPerWorker)
+}
+
+// GetReplayTxSequences reads the transaction sequences captured in [path] and returns the sequence sent by each of [senders],
+// in the same order, stopping at the first sender without a captured sequence. Each captured sequence must be sent by a
+// single address and signed for [chainID]. The transactions are read from the file as the sequences are consumed, and a
+// sequence stops with an error at the first transaction sent by another address.
+func GetReplayTxSequences(ctx context.Context, path string, chainID *big.Int, senders []common.Address) ([]txs.TxSequence[*types.Transaction], error) {
+	sequences, err := txs.ScanTxsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+	sequencesBySender := make(map[common.Address]*txs.FileTxSequence, len(sequences))
+	for i, sequence := range sequences {
+		if sequence.First == nil {
+			continue
+		}
+		sender, err := types.Sender(signer, sequence.First)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover sender of sequence %d: %w", i, err)
+		}
+		if _, exists := sequencesBySender[sender]; exists {
+			return nil, fmt.Errorf("duplicate sequence for sender %s", sender)
+		}
+		sequencesBySender[sender] = sequence
+	}
+
+	txSequences := make([]txs.TxSequence[*types.Transaction], 0, len(senders))
+	for _, sender := range senders {
+		sequence, ok := sequencesBySender[sender]
+		if !ok {
+			break
+		}
+		sender := sender
+		txSequences = append(txSequences, sequence.Stream(ctx, func(tx *types.Transaction) error {
+			txSender, err := types.Sender(signer, tx)
+			if err != nil {
+				return fmt.Errorf("failed to recover sender: %w", err)
+			}
+			if txSender != sender {
+				return fmt.Errorf("sent by %s instead of %s", txSender, sender)
+			}
+			return nil
+		}))
+	}
+	return txSequences, nil
+}
+
+// captureTxSequences writes the transactions of [txSequences] to [path] and returns sequences of the same transactions to issue.
+func captureTxSequences(path string, txSequences []txs.TxSequence[*types.Transaction]) ([]txs.TxSequence[*types.Transaction], error) {
+	sequences, err := txs.CollectTxSequences(txSequences)
+	if err != nil {
+		return nil, err
+	}
+	if err := txs.WriteTxsFile(path, sequences); err != nil {
+		return nil, err
+	}
+	captured := make([]txs.TxSequence[*types.Transaction], len(sequences))
+	for i, sequence := range sequences {
+		captured[i] = txs.ConvertTxSliceToSequence(sequence)
+	}
+	return captured, nil
+}
+
+// estimateDeploymentCost returns the most that deploying [bytecode] costs with a fee cap of at most [gasFeeCap].
+func estimateDeploymentCost(ctx context.Context, client ethclient.Client, gasFeeCap *big.Int, bytecode []byte) (*big.Int, error) {
+	// The sender and fee fields are left out, so the estimate does not depend on the balance of
+	// the deployer, which may not be funded yet.
+	gas, err := client.EstimateGas(ctx, interfaces.CallMsg{
+		Data: bytecode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate contract deployment gas: %w", err)
+	}
+	return new(big.Int).Mul(gasFeeCap, new(big.Int).SetUint64(gas)), nil
+}
+
+// deployContract deploys [bytecode] from [signer] and waits for the deployment to be accepted.
+func deployContract(ctx context.Context, client ethclient.Client, chainID *big.Int, signer key.Signer, gasTipCap *big.Int, gasFeeCap *big.Int, bytecode []byte) (common.Address, error) {
+	from := signer.Address()
+	nonce, err := client.NonceAt(ctx, from, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch nonce for address %s: %w", from, err)
+	}
+	gas, err := client.EstimateGas(ctx, interfaces.CallMsg{
+		From:      from,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Data:      bytecode,
+	})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to estimate contract deployment gas: %w", err)
+	}
+	tx, err := signer.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       gas,
+		Data:      bytecode,
+	}), chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return common.Address{}, fmt.Errorf("failed to issue contract deployment: %w", err)
+	}
+	contractAddr, err := bind.WaitDeployed(ctx, client, tx)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to deploy contract: %w", err)
+	}
+	return contractAddr, nil
+}
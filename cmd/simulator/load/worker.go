@@ -6,6 +6,7 @@ package load
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ava-labs/subnet-evm/core/types"
@@ -15,23 +16,41 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// retryableIssueErrs are the messages of errors returned by SendTransaction when the mempool is congested,
+// matching txpool.ErrTxPoolOverflow and core.ErrNonceTooHigh. They are matched by message since errors
+// returned over RPC do not wrap the original error.
+var retryableIssueErrs = []string{
+	"txpool is full",
+	"nonce too high",
+}
+
 type singleAddressTxWorker struct {
 	client ethclient.Client
+	// issueAttempts is the number of times IssueTx sends a transaction rejected due to congestion.
+	// The wait between attempts starts at issueBackoff and doubles after each rejection.
+	issueAttempts int
+	issueBackoff  time.Duration
 
 	acceptedNonce uint64
 	address       common.Address
+	// submittedNonces are the nonces of the transactions successfully sent by IssueTx.
+	submittedNonces []uint64
 
 	sub      interfaces.Subscription
 	newHeads chan *types.Header
 }
 
-// NewSingleAddressTxWorker creates and returns a singleAddressTxWorker
-func NewSingleAddressTxWorker(ctx context.Context, client ethclient.Client, address common.Address) *singleAddressTxWorker {
+// NewSingleAddressTxWorker creates and returns a singleAddressTxWorker. A transaction rejected because the
+// mempool is congested is sent up to [issueAttempts] times, waiting [issueBackoff] before the first retry
+// and twice as long before each following one.
+func NewSingleAddressTxWorker(ctx context.Context, client ethclient.Client, address common.Address, issueAttempts int, issueBackoff time.Duration) *singleAddressTxWorker {
 	newHeads := make(chan *types.Header)
 	tw := &singleAddressTxWorker{
-		client:   client,
-		address:  address,
-		newHeads: newHeads,
+		client:        client,
+		issueAttempts: issueAttempts,
+		issueBackoff:  issueBackoff,
+		address:       address,
+		newHeads:      newHeads,
 	}
 
 	sub, err := client.SubscribeNewHead(ctx, newHeads)
@@ -45,7 +64,53 @@ func NewSingleAddressTxWorker(ctx context.Context, client ethclient.Client, addr
 }
 
 func (tw *singleAddressTxWorker) IssueTx(ctx context.Context, tx *types.Transaction) error {
-	return tw.client.SendTransaction(ctx, tx)
+	backoff := tw.issueBackoff
+	for i := 1; ; i++ {
+		err := tw.client.SendTransaction(ctx, tx)
+		if err == nil {
+			break
+		}
+		if i >= tw.issueAttempts || !isRetryableIssueErr(err) {
+			return err
+		}
+
+		log.Warn("Mempool congested, retrying to issue tx", "txHash", tx.Hash(), "nonce", tx.Nonce(), "attempt", i+1, "backoff", backoff, "err", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to issue tx %s after congestion: %w", tx.Hash(), ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	tw.submittedNonces = append(tw.submittedNonces, tx.Nonce())
+	return nil
+}
+
+// isRetryableIssueErr returns true if [err] was returned by SendTransaction because the mempool is congested,
+// so that the transaction may be accepted if it is sent again later.
+func isRetryableIssueErr(err error) bool {
+	for _, msg := range retryableIssueErrs {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reconcile compares the submitted transactions against the latest accepted nonce of the worker's address
+// and returns the number of transactions submitted and the number of those whose nonce has been accepted.
+// It must not be called concurrently with IssueTx.
+func (tw *singleAddressTxWorker) Reconcile(ctx context.Context) (submitted uint64, accepted uint64, err error) {
+	acceptedNonce, err := tw.client.NonceAt(ctx, tw.address, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch nonce for address %s: %w", tw.address, err)
+	}
+	for _, nonce := range tw.submittedNonces {
+		if nonce < acceptedNonce {
+			accepted++
+		}
+	}
+	return uint64(len(tw.submittedNonces)), accepted, nil
 }
 
 func (tw *singleAddressTxWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
@@ -4,7 +4,20 @@
 package metrics
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	workerLabel = "worker"
+	stageLabel  = "stage"
+
+	issueStage   = "issue"
+	confirmStage = "confirm"
 )
 
 type Metrics struct {
@@ -14,6 +27,25 @@ type Metrics struct {
 	ConfirmationTxTimes prometheus.Summary
 	// Summary of the quantiles of Individual Issuance To Confirmation Tx Times
 	IssuanceToConfirmationTxTimes prometheus.Summary
+	// Count of Issued Txs per Worker
+	WorkerIssuedTxs *prometheus.CounterVec
+	// Count of Confirmed Txs per Worker
+	WorkerConfirmedTxs *prometheus.CounterVec
+	// Summary of the quantiles of Individual Issuance To Confirmation Tx Times per Worker
+	WorkerIssuanceToConfirmationTxTimes *prometheus.SummaryVec
+	// Count of Txs that failed to be issued or confirmed per Worker and stage
+	WorkerTxErrors *prometheus.CounterVec
+}
+
+// WorkerStats is the structured throughput and latency report of a single worker.
+// Latencies are in seconds.
+type WorkerStats struct {
+	Worker       int     `json:"worker"`
+	IssuedTxs    uint64  `json:"issuedTxs"`
+	ConfirmedTxs uint64  `json:"confirmedTxs"`
+	LatencyP50   float64 `json:"latencyP50"`
+	LatencyP90   float64 `json:"latencyP90"`
+	LatencyP99   float64 `json:"latencyP99"`
 }
 
 // NewMetrics creates and returns a Metrics and registers it with a Collector
@@ -34,9 +66,108 @@ func NewMetrics(reg prometheus.Registerer) *Metrics {
 			Help:       "Individual Tx Issuance To Confirmation Times for a Load Test",
 			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 		}),
+		WorkerIssuedTxs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "worker_txs_issued",
+			Help: "Number of Txs Issued by each Worker for a Load Test",
+		}, []string{workerLabel}),
+		WorkerConfirmedTxs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "worker_txs_confirmed",
+			Help: "Number of Txs Confirmed for each Worker for a Load Test",
+		}, []string{workerLabel}),
+		WorkerIssuanceToConfirmationTxTimes: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       "worker_tx_issuance_to_confirmation_time",
+			Help:       "Individual Tx Issuance To Confirmation Times for each Worker for a Load Test",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}, []string{workerLabel}),
+		WorkerTxErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "worker_tx_errors",
+			Help: "Number of Txs that failed to be issued or confirmed by each Worker for a Load Test",
+		}, []string{workerLabel, stageLabel}),
 	}
 	reg.MustRegister(m.IssuanceTxTimes)
 	reg.MustRegister(m.ConfirmationTxTimes)
 	reg.MustRegister(m.IssuanceToConfirmationTxTimes)
+	reg.MustRegister(m.WorkerIssuedTxs)
+	reg.MustRegister(m.WorkerConfirmedTxs)
+	reg.MustRegister(m.WorkerIssuanceToConfirmationTxTimes)
+	reg.MustRegister(m.WorkerTxErrors)
 	return m
 }
+
+// ForWorker returns the metrics of worker [i].
+func (m *Metrics) ForWorker(i int) *WorkerMetrics {
+	worker := strconv.Itoa(i)
+	return &WorkerMetrics{
+		IssuedTxs:                     m.WorkerIssuedTxs.WithLabelValues(worker),
+		ConfirmedTxs:                  m.WorkerConfirmedTxs.WithLabelValues(worker),
+		IssuanceToConfirmationTxTimes: m.WorkerIssuanceToConfirmationTxTimes.WithLabelValues(worker),
+		IssueErrors:                   m.WorkerTxErrors.WithLabelValues(worker, issueStage),
+		ConfirmErrors:                 m.WorkerTxErrors.WithLabelValues(worker, confirmStage),
+	}
+}
+
+// WorkerStats returns the report of each of the first [numWorkers] workers.
+func (m *Metrics) WorkerStats(numWorkers int) ([]WorkerStats, error) {
+	stats := make([]WorkerStats, 0, numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		workerMetrics := m.ForWorker(i)
+		issued, err := counterValue(workerMetrics.IssuedTxs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read issued txs of worker %d: %w", i, err)
+		}
+		confirmed, err := counterValue(workerMetrics.ConfirmedTxs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read confirmed txs of worker %d: %w", i, err)
+		}
+		quantiles, err := summaryQuantiles(workerMetrics.IssuanceToConfirmationTxTimes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read latency of worker %d: %w", i, err)
+		}
+		stats = append(stats, WorkerStats{
+			Worker:       i,
+			IssuedTxs:    uint64(issued),
+			ConfirmedTxs: uint64(confirmed),
+			LatencyP50:   quantiles[0.5],
+			LatencyP90:   quantiles[0.9],
+			LatencyP99:   quantiles[0.99],
+		})
+	}
+	return stats, nil
+}
+
+// WorkerMetrics are the metrics of a single worker.
+type WorkerMetrics struct {
+	IssuedTxs                     prometheus.Counter
+	ConfirmedTxs                  prometheus.Counter
+	IssuanceToConfirmationTxTimes prometheus.Observer
+	IssueErrors                   prometheus.Counter
+	ConfirmErrors                 prometheus.Counter
+}
+
+func counterValue(counter prometheus.Counter) (float64, error) {
+	var metric dto.Metric
+	if err := counter.Write(&metric); err != nil {
+		return 0, err
+	}
+	return metric.GetCounter().GetValue(), nil
+}
+
+func summaryQuantiles(observer prometheus.Observer) (map[float64]float64, error) {
+	summary, ok := observer.(prometheus.Metric)
+	if !ok {
+		return nil, fmt.Errorf("unexpected observer type %T", observer)
+	}
+	var metric dto.Metric
+	if err := summary.Write(&metric); err != nil {
+		return nil, err
+	}
+	quantiles := make(map[float64]float64)
+	for _, q := range metric.GetSummary().GetQuantile() {
+		// Quantiles are NaN until the first observation, which cannot be encoded as JSON.
+		if math.IsNaN(q.GetValue()) {
+			continue
+		}
+		quantiles[q.GetQuantile()] = q.GetValue()
+	}
+	return quantiles, nil
+}
@@ -23,6 +23,9 @@ type THash interface {
 // transactions.
 type TxSequence[T THash] interface {
 	Chan() <-chan T
+	// Err returns the error that caused the sequence to close its channel early, if any.
+	// It is only valid after the channel has been closed.
+	Err() error
 }
 
 // Worker defines the interface for issuance and confirmation of transactions.
@@ -45,15 +48,18 @@ type issueNAgent[T THash] struct {
 	worker   Worker[T]
 	n        uint64
 	metrics  *metrics.Metrics
+	// workerMetrics are the metrics of this agent, labelled by the worker index it was created with
+	workerMetrics *metrics.WorkerMetrics
 }
 
-// NewIssueNAgent creates a new issueNAgent
-func NewIssueNAgent[T THash](sequence TxSequence[T], worker Worker[T], n uint64, metrics *metrics.Metrics) Agent[T] {
+// NewIssueNAgent creates a new issueNAgent for the worker at index [workerIndex]
+func NewIssueNAgent[T THash](sequence TxSequence[T], worker Worker[T], n uint64, metrics *metrics.Metrics, workerIndex int) Agent[T] {
 	return &issueNAgent[T]{
-		sequence: sequence,
-		worker:   worker,
-		n:        n,
-		metrics:  metrics,
+		sequence:      sequence,
+		worker:        worker,
+		n:             n,
+		metrics:       metrics,
+		workerMetrics: metrics.ForWorker(workerIndex),
 	}
 }
 
@@ -67,6 +73,7 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 	confirmedCount := 0
 	batchI := 0
 	m := a.metrics
+	wm := a.workerMetrics
 	txMap := make(map[common.Hash]time.Time)
 
 	// Tracks the total amount of time waiting for issuing and confirming txs
@@ -103,10 +110,12 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 				issuanceIndividualStart := time.Now()
 				txMap[tx.Hash()] = issuanceIndividualStart
 				if err := a.worker.IssueTx(ctx, tx); err != nil {
+					wm.IssueErrors.Inc()
 					return fmt.Errorf("failed to issue transaction %d: %w", len(txs), err)
 				}
 				issuanceIndividualDuration := time.Since(issuanceIndividualStart)
 				m.IssuanceTxTimes.Observe(issuanceIndividualDuration.Seconds())
+				wm.IssuedTxs.Inc()
 				txs = append(txs, tx)
 			}
 		}
@@ -120,12 +129,15 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 		for i, tx := range txs {
 			confirmedIndividualStart := time.Now()
 			if err := a.worker.ConfirmTx(ctx, tx); err != nil {
+				wm.ConfirmErrors.Inc()
 				return fmt.Errorf("failed to await transaction %d: %w", i, err)
 			}
 			confirmationIndividualDuration := time.Since(confirmedIndividualStart)
 			issuanceToConfirmationIndividualDuration := time.Since(txMap[tx.Hash()])
 			m.ConfirmationTxTimes.Observe(confirmationIndividualDuration.Seconds())
 			m.IssuanceToConfirmationTxTimes.Observe(issuanceToConfirmationIndividualDuration.Seconds())
+			wm.ConfirmedTxs.Inc()
+			wm.IssuanceToConfirmationTxTimes.Observe(issuanceToConfirmationIndividualDuration.Seconds())
 			delete(txMap, tx.Hash())
 			confirmedCount++
 		}
@@ -136,6 +148,9 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 
 		// Check if this is the last batch, if so write the final log and return
 		if !moreTxs {
+			if err := a.sequence.Err(); err != nil {
+				return fmt.Errorf("tx sequence ended early after %d txs: %w", confirmedCount, err)
+			}
 			totalTime := time.Since(start).Seconds()
 			log.Info("Execution complete", "totalTxs", confirmedCount, "totalTime", totalTime, "TPS", float64(confirmedCount)/totalTime,
 				"issuanceTime", totalIssuedTime.Seconds(), "confirmedTime", totalConfirmedTime.Seconds())
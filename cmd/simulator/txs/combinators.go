@@ -0,0 +1,165 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/key"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"golang.org/x/time/rate"
+)
+
+// chanSequence is a TxSequence fed by a goroutine that sets err before closing txChan.
+type chanSequence[T THash] struct {
+	txChan chan T
+	err    error
+}
+
+func (s *chanSequence[T]) Chan() <-chan T {
+	return s.txChan
+}
+
+func (s *chanSequence[T]) Err() error {
+	return s.err
+}
+
+// Interleave returns a sequence that takes one transaction from each of [seqs] in turn until all of them
+// are exhausted. The order of the transactions within each sequence is preserved, so sequences signed by
+// the same key keep their nonces in order. Its Err joins the errors of [seqs].
+func Interleave[T THash](seqs ...TxSequence[T]) TxSequence[T] {
+	sequence := &chanSequence[T]{
+		txChan: make(chan T, streamBufferSize),
+	}
+	go func() {
+		defer close(sequence.txChan)
+
+		open := make([]TxSequence[T], len(seqs))
+		copy(open, seqs)
+		for len(open) > 0 {
+			remaining := open[:0]
+			for _, seq := range open {
+				tx, ok := <-seq.Chan()
+				if !ok {
+					continue
+				}
+				sequence.txChan <- tx
+				remaining = append(remaining, seq)
+			}
+			open = remaining
+		}
+
+		errs := make([]error, 0, len(seqs))
+		for _, seq := range seqs {
+			errs = append(errs, seq.Err())
+		}
+		sequence.err = errors.Join(errs...)
+	}()
+	return sequence
+}
+
+// TxSource describes the transactions of one kind generated by InterleaveNonces: [NumTxs] transactions
+// created by [Generator] and signed by [Signer].
+type TxSource struct {
+	Generator CreateTx
+	Signer    key.Signer
+	NumTxs    uint64
+}
+
+// InterleaveNonces returns a sequence that takes one transaction from each of [sources] in turn, as Interleave does,
+// until each has generated its NumTxs transactions. Each transaction is generated when the previous one has been
+// handed out, and its nonce is reserved from [nonces] only then, so sources signed by the same key hand out their
+// nonces in strict order. Sources sharing a key must be passed to the same call. Generation stops if [ctx] is done
+// or a generator fails, which is reported by Err, and the nonce of the transaction that was not handed out is released.
+// If the sequence is cut short by Take, that nonce is released once [ctx] is done.
+func InterleaveNonces(ctx context.Context, nonces *Nonces, sources ...TxSource) TxSequence[*types.Transaction] {
+	sequence := &chanSequence[*types.Transaction]{
+		txChan: make(chan *types.Transaction),
+	}
+	go func() {
+		defer close(sequence.txChan)
+
+		generated := make([]uint64, len(sources))
+		for remaining := true; remaining; {
+			remaining = false
+			for i, source := range sources {
+				if generated[i] >= source.NumTxs {
+					continue
+				}
+				remaining = true
+
+				address := source.Signer.Address()
+				nonce := nonces.Next(address)
+				tx, err := source.Generator(source.Signer, nonce)
+				if err != nil {
+					nonces.release(address, nonce)
+					sequence.err = fmt.Errorf("failed to sign tx at index %d of source %d: %w", generated[i], i, err)
+					return
+				}
+				select {
+				case sequence.txChan <- tx:
+				case <-ctx.Done():
+					nonces.release(address, nonce)
+					sequence.err = ctx.Err()
+					return
+				}
+				generated[i]++
+			}
+		}
+	}()
+	return sequence
+}
+
+// Take returns a sequence of at most the first [n] transactions of [seq].
+// If [seq] is generated from a context, the remainder of [seq] is not consumed and its generation
+// only stops once that context is cancelled. Its Err is the error of [seq] if [seq] closed before
+// [n] transactions were taken.
+func Take[T THash](seq TxSequence[T], n uint64) TxSequence[T] {
+	sequence := &chanSequence[T]{
+		txChan: make(chan T, streamBufferSize),
+	}
+	go func() {
+		defer close(sequence.txChan)
+
+		for i := uint64(0); i < n; i++ {
+			tx, ok := <-seq.Chan()
+			if !ok {
+				sequence.err = seq.Err()
+				return
+			}
+			sequence.txChan <- tx
+		}
+	}()
+	return sequence
+}
+
+// RateLimit returns a sequence of the transactions of [seq] that waits on [limiter] before handing out each
+// transaction, so that sequences sharing [limiter] are consumed at its combined rate. Transactions are not
+// buffered, so each one is issued as soon as the limiter allows it. Its Err is the error of [seq], or the
+// error of [ctx] if it is done before [seq] is exhausted.
+func RateLimit[T THash](ctx context.Context, seq TxSequence[T], limiter *rate.Limiter) TxSequence[T] {
+	sequence := &chanSequence[T]{
+		txChan: make(chan T),
+	}
+	go func() {
+		defer close(sequence.txChan)
+
+		for tx := range seq.Chan() {
+			if err := limiter.Wait(ctx); err != nil {
+				sequence.err = err
+				return
+			}
+			select {
+			case sequence.txChan <- tx:
+			case <-ctx.Done():
+				sequence.err = ctx.Err()
+				return
+			}
+		}
+		sequence.err = seq.Err()
+	}()
+	return sequence
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/key"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var testChainID = big.NewInt(1)
+
+// testTx identifies a transaction of a test sequence by the index of its sender's key and its nonce.
+type testTx struct {
+	sender int
+	nonce  uint64
+}
+
+func newTestKeys(t *testing.T, n int) []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+	return keys
+}
+
+func newTestTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: nonce}), types.LatestSignerForChainID(testChainID), key)
+	require.NoError(t, err)
+	return tx
+}
+
+// newTestSequences returns a sequence of the transactions of each of [seqs] and a lookup of the
+// transactions by hash.
+func newTestSequences(t *testing.T, keys []*ecdsa.PrivateKey, seqs [][]testTx) ([]TxSequence[*types.Transaction], map[common.Hash]testTx) {
+	byHash := make(map[common.Hash]testTx)
+	sequences := make([]TxSequence[*types.Transaction], len(seqs))
+	for i, seq := range seqs {
+		txs := make([]*types.Transaction, len(seq))
+		for j, testTx := range seq {
+			txs[j] = newTestTx(t, keys[testTx.sender], testTx.nonce)
+			byHash[txs[j].Hash()] = testTx
+		}
+		sequences[i] = ConvertTxSliceToSequence(txs)
+	}
+	return sequences, byHash
+}
+
+func TestInterleave(t *testing.T) {
+	require := require.New(t)
+
+	keys := newTestKeys(t, 2)
+	seqs, byHash := newTestSequences(t, keys, [][]testTx{
+		{{0, 0}, {0, 1}, {0, 2}},
+		{{1, 0}},
+	})
+
+	sequence := Interleave(seqs...)
+	handedOut := make([]testTx, 0)
+	for tx := range sequence.Chan() {
+		handedOut = append(handedOut, byHash[tx.Hash()])
+	}
+	require.Equal([]testTx{{0, 0}, {1, 0}, {0, 1}, {0, 2}}, handedOut)
+	require.NoError(sequence.Err())
+}
+
+func TestTake(t *testing.T) {
+	keys := newTestKeys(t, 1)
+
+	tests := []struct {
+		name     string
+		n        uint64
+		expected []testTx
+	}{
+		{
+			name:     "fewer than available",
+			n:        2,
+			expected: []testTx{{0, 0}, {0, 1}},
+		},
+		{
+			name:     "more than available",
+			n:        5,
+			expected: []testTx{{0, 0}, {0, 1}, {0, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			seqs, byHash := newTestSequences(t, keys, [][]testTx{{{0, 0}, {0, 1}, {0, 2}}})
+			sequence := Take(seqs[0], tt.n)
+			handedOut := make([]testTx, 0, len(tt.expected))
+			for tx := range sequence.Chan() {
+				handedOut = append(handedOut, byHash[tx.Hash()])
+			}
+			require.Equal(tt.expected, handedOut)
+			require.NoError(sequence.Err())
+		})
+	}
+}
+
+// newTestSigners returns a signer for each of [keys].
+func newTestSigners(keys []*ecdsa.PrivateKey) []key.Signer {
+	signers := make([]key.Signer, len(keys))
+	for i, pk := range keys {
+		signers[i] = (&key.Key{PrivKey: pk, Address: crypto.PubkeyToAddress(pk.PublicKey)}).Signer()
+	}
+	return signers
+}
+
+// testTxGenerator is a CreateTx that signs an empty transaction.
+func testTxGenerator(signer key.Signer, nonce uint64) (*types.Transaction, error) {
+	return signer.SignTx(types.NewTx(&types.LegacyTx{Nonce: nonce}), testChainID)
+}
+
+// nextNonce returns the next nonce [nonces] hands out for [address] without reserving it.
+func nextNonce(nonces *Nonces, address common.Address) uint64 {
+	nonces.lock.Lock()
+	defer nonces.lock.Unlock()
+
+	return nonces.next[address]
+}
+
+func TestInterleaveNonces(t *testing.T) {
+	require := require.New(t)
+
+	signers := newTestSigners(newTestKeys(t, 2))
+	nonces := &Nonces{
+		next: map[common.Address]uint64{signers[0].Address(): 5},
+	}
+	sequence := InterleaveNonces(context.Background(), nonces,
+		TxSource{Generator: testTxGenerator, Signer: signers[0], NumTxs: 2},
+		TxSource{Generator: testTxGenerator, Signer: signers[0], NumTxs: 1},
+		TxSource{Generator: testTxGenerator, Signer: signers[1], NumTxs: 2},
+	)
+
+	senders := map[common.Address]int{signers[0].Address(): 0, signers[1].Address(): 1}
+	handedOut := make([]testTx, 0)
+	for tx := range sequence.Chan() {
+		sender, err := types.Sender(types.LatestSignerForChainID(testChainID), tx)
+		require.NoError(err)
+		handedOut = append(handedOut, testTx{senders[sender], tx.Nonce()})
+	}
+	require.NoError(sequence.Err())
+	// The sources of the first signer draw from one counter, so their nonces are in strict order.
+	require.Equal([]testTx{{0, 5}, {0, 6}, {1, 0}, {0, 7}, {1, 1}}, handedOut)
+	require.Equal(uint64(8), nextNonce(nonces, signers[0].Address()))
+	require.Equal(uint64(2), nextNonce(nonces, signers[1].Address()))
+}
+
+func TestInterleaveNoncesReleasesUnusedNonce(t *testing.T) {
+	signers := newTestSigners(newTestKeys(t, 1))
+	address := signers[0].Address()
+	errGenerator := errors.New("generator failed")
+
+	t.Run("cut short by Take", func(t *testing.T) {
+		require := require.New(t)
+		nonces := &Nonces{
+			next: make(map[common.Address]uint64),
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		sequence := Take(InterleaveNonces(ctx, nonces, TxSource{Generator: testTxGenerator, Signer: signers[0], NumTxs: 5}), 2)
+		handedOut := make([]uint64, 0)
+		for tx := range sequence.Chan() {
+			handedOut = append(handedOut, tx.Nonce())
+		}
+		require.NoError(sequence.Err())
+		require.Equal([]uint64{0, 1}, handedOut)
+
+		// The nonce of the transaction generated but never taken is released once the context is cancelled.
+		cancel()
+		require.Eventually(func() bool {
+			return nextNonce(nonces, address) == 2
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("generator error", func(t *testing.T) {
+		require := require.New(t)
+		nonces := &Nonces{
+			next: make(map[common.Address]uint64),
+		}
+
+		calls := 0
+		generator := func(signer key.Signer, nonce uint64) (*types.Transaction, error) {
+			calls++
+			if calls > 1 {
+				return nil, errGenerator
+			}
+			return testTxGenerator(signer, nonce)
+		}
+		sequence := InterleaveNonces(context.Background(), nonces, TxSource{Generator: generator, Signer: signers[0], NumTxs: 3})
+		handedOut := make([]uint64, 0)
+		for tx := range sequence.Chan() {
+			handedOut = append(handedOut, tx.Nonce())
+		}
+		require.ErrorIs(sequence.Err(), errGenerator)
+		require.Equal([]uint64{0}, handedOut)
+		require.Equal(uint64(1), nextNonce(nonces, address))
+	})
+}
@@ -0,0 +1,317 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// txsFile is the format of a file of captured transaction sequences.
+// Each sequence is a list of signed transactions in their binary encoding.
+type txsFile struct {
+	Sequences [][]hexutil.Bytes `json:"sequences"`
+}
+
+// CollectTxSequences drains each of [seqs] into a slice of transactions, so that the sequences can be
+// written to a file and then issued with ConvertTxSliceToSequence. Unlike the sequences themselves,
+// the returned transactions are all held in memory.
+func CollectTxSequences(seqs []TxSequence[*types.Transaction]) ([][]*types.Transaction, error) {
+	collected := make([][]*types.Transaction, len(seqs))
+	for i, seq := range seqs {
+		for tx := range seq.Chan() {
+			collected[i] = append(collected[i], tx)
+		}
+		if err := seq.Err(); err != nil {
+			return nil, fmt.Errorf("failed to generate sequence %d: %w", i, err)
+		}
+	}
+	return collected, nil
+}
+
+// WriteTxsFile writes [sequences] to [path] as JSON, in the format read by ScanTxsFile.
+// If [path] has a .gz extension, the file is gzip compressed.
+func WriteTxsFile(path string, sequences [][]*types.Transaction) (err error) {
+	file := txsFile{
+		Sequences: make([][]hexutil.Bytes, len(sequences)),
+	}
+	for i, sequence := range sequences {
+		file.Sequences[i] = make([]hexutil.Bytes, len(sequence))
+		for j, tx := range sequence {
+			txBytes, err := tx.MarshalBinary()
+			if err != nil {
+				return fmt.Errorf("failed to marshal tx %d of sequence %d: %w", j, i, err)
+			}
+			file.Sequences[i][j] = txBytes
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create tx sequences file %s: %w", path, err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to close tx sequences file %s: %w", path, closeErr)
+		}
+	}()
+
+	var gzipWriter *gzip.Writer
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	if isGzipPath(path) {
+		gzipWriter = gzip.NewWriter(w)
+		enc = json.NewEncoder(gzipWriter)
+	}
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return fmt.Errorf("failed to write tx sequences to %s: %w", path, err)
+	}
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			return fmt.Errorf("failed to compress tx sequences to %s: %w", path, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write tx sequences to %s: %w", path, err)
+	}
+	return nil
+}
+
+// FileTxSequence is a transaction sequence of a file written by WriteTxsFile. Only its first transaction is held
+// in memory, the rest are read from the file when the sequence is streamed.
+type FileTxSequence struct {
+	path  string
+	index int
+	// First is the first transaction of the sequence, or nil if the sequence is empty.
+	First *types.Transaction
+}
+
+// ScanTxsFile reads the transaction sequences written to [path] by WriteTxsFile.
+// The transactions may also be written by hand, as long as each is signed and binary encoded.
+// If [path] has a .gz extension, the file is decompressed as it is read. The file is decoded one
+// transaction at a time and only the first transaction of each sequence is kept.
+func ScanTxsFile(path string) ([]*FileTxSequence, error) {
+	r, err := openTxsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var sequences []*FileTxSequence
+	for i := 0; ; i++ {
+		ok, err := r.nextSequence()
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tx sequences from %s: %w", path, err)
+		}
+		if !ok {
+			break
+		}
+		first, err := r.nextTx()
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tx 0 of sequence %d from %s: %w", i, path, err)
+		}
+		if first != nil {
+			if err := r.skipSequence(); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal sequence %d from %s: %w", i, path, err)
+			}
+		}
+		sequences = append(sequences, &FileTxSequence{
+			path:  path,
+			index: i,
+			First: first,
+		})
+	}
+	return sequences, nil
+}
+
+// Stream returns a sequence of the transactions of [s], decoded from its file as they are consumed.
+// Each transaction is passed to [check] before it is handed out, and the sequence stops at the first
+// transaction [check] rejects. At most [streamBufferSize] transactions are decoded ahead of the consumer.
+// The file is read from the start, skipping the sequences before [s].
+func (s *FileTxSequence) Stream(ctx context.Context, check func(tx *types.Transaction) error) TxSequence[*types.Transaction] {
+	sequence := &txSequence{
+		txChan: make(chan *types.Transaction, streamBufferSize),
+	}
+	go func() {
+		defer close(sequence.txChan)
+		sequence.err = s.stream(ctx, check, sequence.txChan)
+	}()
+	return sequence
+}
+
+func (s *FileTxSequence) stream(ctx context.Context, check func(tx *types.Transaction) error, txChan chan<- *types.Transaction) error {
+	r, err := openTxsFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for i := 0; i <= s.index; i++ {
+		ok, err := r.nextSequence()
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal tx sequences from %s: %w", s.path, err)
+		}
+		if !ok {
+			return fmt.Errorf("tx sequence %d not found in %s", s.index, s.path)
+		}
+		if i == s.index {
+			break
+		}
+		if err := r.skipSequence(); err != nil {
+			return fmt.Errorf("failed to unmarshal sequence %d from %s: %w", i, s.path, err)
+		}
+	}
+
+	for j := 0; ; j++ {
+		tx, err := r.nextTx()
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal tx %d of sequence %d from %s: %w", j, s.index, s.path, err)
+		}
+		if tx == nil {
+			return nil
+		}
+		if err := check(tx); err != nil {
+			return fmt.Errorf("tx %d of sequence %d: %w", j, s.index, err)
+		}
+		select {
+		case txChan <- tx:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// txsFileReader decodes the sequences of a txsFile one transaction at a time.
+// As with json.Unmarshal, fields other than the sequences are ignored.
+type txsFileReader struct {
+	closers []io.Closer
+	dec     *json.Decoder
+	// done is true once the end of the sequences has been read.
+	done bool
+}
+
+// openTxsFile opens the txsFile at [path] and reads up to the start of its first sequence.
+func openTxsFile(path string) (*txsFileReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tx sequences from %s: %w", path, err)
+	}
+	r := &txsFileReader{
+		closers: []io.Closer{f},
+	}
+
+	var reader io.Reader = bufio.NewReader(f)
+	if isGzipPath(path) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			r.Close()
+			return nil, fmt.Errorf("failed to decompress tx sequences from %s: %w", path, err)
+		}
+		r.closers = append(r.closers, gzipReader)
+		reader = gzipReader
+	}
+	r.dec = json.NewDecoder(reader)
+
+	if err := r.seekSequences(); err != nil {
+		r.Close()
+		return nil, fmt.Errorf("failed to unmarshal tx sequences from %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// seekSequences reads up to the start of the sequences array, skipping any other fields before it.
+// If the file has no sequences, the reader is marked done.
+func (r *txsFileReader) seekSequences() error {
+	if err := expectDelim(r.dec, '{'); err != nil {
+		return err
+	}
+	for r.dec.More() {
+		key, err := r.dec.Token()
+		if err != nil {
+			return err
+		}
+		if key == "sequences" {
+			return expectDelim(r.dec, '[')
+		}
+		var ignored json.RawMessage
+		if err := r.dec.Decode(&ignored); err != nil {
+			return err
+		}
+	}
+	r.done = true
+	return expectDelim(r.dec, '}')
+}
+
+// nextSequence reads up to the start of the next sequence and returns false if there are no more sequences.
+func (r *txsFileReader) nextSequence() (bool, error) {
+	if r.done {
+		return false, nil
+	}
+	if !r.dec.More() {
+		r.done = true
+		return false, expectDelim(r.dec, ']')
+	}
+	return true, expectDelim(r.dec, '[')
+}
+
+// nextTx decodes the next transaction of the current sequence and returns nil at the end of the sequence.
+func (r *txsFileReader) nextTx() (*types.Transaction, error) {
+	if !r.dec.More() {
+		return nil, expectDelim(r.dec, ']')
+	}
+	var txBytes hexutil.Bytes
+	if err := r.dec.Decode(&txBytes); err != nil {
+		return nil, err
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(txBytes); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// skipSequence reads past the remaining transactions of the current sequence without unmarshalling them.
+func (r *txsFileReader) skipSequence() error {
+	for r.dec.More() {
+		var ignored json.RawMessage
+		if err := r.dec.Decode(&ignored); err != nil {
+			return err
+		}
+	}
+	return expectDelim(r.dec, ']')
+}
+
+// Close closes the file of [r].
+func (r *txsFileReader) Close() {
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		_ = r.closers[i].Close()
+	}
+}
+
+// expectDelim reads the next token of [dec] and returns an error unless it is [delim].
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q, found %v", delim, token)
+	}
+	return nil
+}
+
+// isGzipPath returns true if the file at [path] is gzip compressed, based on its extension.
+func isGzipPath(path string) bool {
+	return filepath.Ext(path) == ".gz"
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxsFileRoundTrip(t *testing.T) {
+	keys := newTestKeys(t, 2)
+	sequences := [][]*types.Transaction{
+		{newTestTx(t, keys[0], 0), newTestTx(t, keys[0], 1)},
+		{newTestTx(t, keys[1], 0)},
+		{},
+	}
+
+	tests := []struct {
+		name string
+		file string
+		// prefix is the start of the written file.
+		prefix []byte
+	}{
+		{
+			name:   "json",
+			file:   "txs.json",
+			prefix: []byte("{"),
+		},
+		{
+			name:   "gzip",
+			file:   "txs.json.gz",
+			prefix: []byte{0x1f, 0x8b}, // gzip magic number
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			path := filepath.Join(t.TempDir(), tt.file)
+
+			require.NoError(WriteTxsFile(path, sequences))
+			written, err := os.ReadFile(path)
+			require.NoError(err)
+			require.True(bytes.HasPrefix(written, tt.prefix))
+
+			read, err := ScanTxsFile(path)
+			require.NoError(err)
+			require.Len(read, len(sequences))
+			for i, sequence := range sequences {
+				if len(sequence) == 0 {
+					require.Nil(read[i].First)
+				} else {
+					require.Equal(sequence[0].Hash(), read[i].First.Hash())
+				}
+
+				streamed := read[i].Stream(context.Background(), func(*types.Transaction) error { return nil })
+				j := 0
+				for tx := range streamed.Chan() {
+					require.Equal(sequence[j].Hash(), tx.Hash())
+					j++
+				}
+				require.NoError(streamed.Err())
+				require.Equal(len(sequence), j)
+			}
+		})
+	}
+}
+
+func TestTxsFileStreamCheck(t *testing.T) {
+	require := require.New(t)
+	keys := newTestKeys(t, 1)
+	path := filepath.Join(t.TempDir(), "txs.json")
+	require.NoError(WriteTxsFile(path, [][]*types.Transaction{
+		{newTestTx(t, keys[0], 0)},
+		{newTestTx(t, keys[0], 0), newTestTx(t, keys[0], 1), newTestTx(t, keys[0], 2)},
+	}))
+
+	read, err := ScanTxsFile(path)
+	require.NoError(err)
+	require.Len(read, 2)
+
+	// The sequence stops at the first transaction rejected by the check.
+	errRejected := errors.New("rejected")
+	streamed := read[1].Stream(context.Background(), func(tx *types.Transaction) error {
+		if tx.Nonce() == 1 {
+			return errRejected
+		}
+		return nil
+	})
+	nonces := make([]uint64, 0)
+	for tx := range streamed.Chan() {
+		nonces = append(nonces, tx.Nonce())
+	}
+	require.Equal([]uint64{0}, nonces)
+	require.ErrorIs(streamed.Err(), errRejected)
+}
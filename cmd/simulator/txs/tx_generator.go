@@ -5,50 +5,144 @@ package txs
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
+	"math/big"
+	"sync"
 
+	"github.com/ava-labs/subnet-evm/cmd/simulator/key"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/ethclient"
-	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 var _ TxSequence[*types.Transaction] = (*txSequence)(nil)
 
-type CreateTx func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error)
+// streamBufferSize is the number of transactions a streamed sequence generates ahead of the consumer.
+const streamBufferSize = 256
 
-// GenerateTxSequence fetches the current nonce of key and calls [generator] [numTxs] times sequentially to generate a sequence of transactions.
-func GenerateTxSequence(ctx context.Context, generator CreateTx, client ethclient.Client, key *ecdsa.PrivateKey, numTxs uint64) (TxSequence[*types.Transaction], error) {
-	address := ethcrypto.PubkeyToAddress(key.PublicKey)
-	startingNonce, err := client.NonceAt(ctx, address, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch nonce for address %s: %w", address, err)
-	}
-	txs := make([]*types.Transaction, 0, numTxs)
-	for i := uint64(0); i < numTxs; i++ {
-		tx, err := generator(key, startingNonce+i)
+// CreateTx returns the transaction with [nonce] signed by [signer].
+type CreateTx func(signer key.Signer, nonce uint64) (*types.Transaction, error)
+
+// FetchStartingNonces returns the pending nonce of the address of each of [signers], so that sequences started right
+// after a previous run do not reuse nonces of transactions still in the mempool.
+func FetchStartingNonces(ctx context.Context, client ethclient.Client, signers []key.Signer) ([]uint64, error) {
+	pendingBlockNumber := big.NewInt(int64(rpc.PendingBlockNumber))
+	nonces := make([]uint64, len(signers))
+	for i, signer := range signers {
+		address := signer.Address()
+		nonce, err := client.NonceAt(ctx, address, pendingBlockNumber)
 		if err != nil {
-			return nil, fmt.Errorf("failed to sign tx at index %d: %w", i, err)
+			return nil, fmt.Errorf("failed to fetch nonce for address %s: %w", address, err)
 		}
-		txs = append(txs, tx)
+		nonces[i] = nonce
 	}
-	return ConvertTxSliceToSequence(txs), nil
+	return nonces, nil
 }
 
-func GenerateTxSequences(ctx context.Context, generator CreateTx, client ethclient.Client, keys []*ecdsa.PrivateKey, txsPerKey uint64) ([]TxSequence[*types.Transaction], error) {
-	txSequences := make([]TxSequence[*types.Transaction], len(keys))
-	for i, key := range keys {
-		txs, err := GenerateTxSequence(ctx, generator, client, key, txsPerKey)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate tx sequence at index %d: %w", i, err)
+// Nonces hands out the nonces of a set of addresses, so that several sequences signed by the same key can draw
+// from one counter and together never reuse or skip a nonce. It is safe for concurrent use.
+type Nonces struct {
+	lock sync.Mutex
+	next map[common.Address]uint64
+}
+
+// FetchNonces returns Nonces starting at the pending nonce of the address of each of [signers].
+// Signers with the same address share a counter.
+func FetchNonces(ctx context.Context, client ethclient.Client, signers []key.Signer) (*Nonces, error) {
+	startingNonces, err := FetchStartingNonces(ctx, client, signers)
+	if err != nil {
+		return nil, err
+	}
+	nonces := &Nonces{
+		next: make(map[common.Address]uint64, len(signers)),
+	}
+	for i, signer := range signers {
+		nonces.next[signer.Address()] = startingNonces[i]
+	}
+	return nonces, nil
+}
+
+// Next reserves and returns the next nonce of [address]. An address that is not tracked starts at 0.
+func (n *Nonces) Next(address common.Address) uint64 {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	nonce := n.next[address]
+	n.next[address] = nonce + 1
+	return nonce
+}
+
+// release undoes the reservation of [nonce] for [address] if no later nonce has been reserved since,
+// so that a transaction that is never handed out does not leave a gap.
+func (n *Nonces) release(address common.Address, nonce uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.next[address] == nonce+1 {
+		n.next[address] = nonce
+	}
+}
+
+// GenerateTxSequence fetches the pending nonce of [signer] and returns a sequence that lazily calls [generator] [numTxs] times sequentially.
+func GenerateTxSequence(ctx context.Context, generator CreateTx, client ethclient.Client, signer key.Signer, numTxs uint64) (TxSequence[*types.Transaction], error) {
+	startingNonces, err := FetchStartingNonces(ctx, client, []key.Signer{signer})
+	if err != nil {
+		return nil, err
+	}
+	return GenerateTxSequenceFromNonce(ctx, generator, signer, startingNonces[0], numTxs), nil
+}
+
+// GenerateTxSequenceFromNonce returns a sequence that lazily calls [generator] [numTxs] times sequentially, starting at [startingNonce].
+// At most [streamBufferSize] transactions are generated ahead of the consumer, so memory use does not grow with [numTxs].
+// Generation stops early if [ctx] is cancelled or [generator] fails, which is reported by the sequence's Err.
+func GenerateTxSequenceFromNonce(ctx context.Context, generator CreateTx, signer key.Signer, startingNonce uint64, numTxs uint64) TxSequence[*types.Transaction] {
+	sequence := &txSequence{
+		txChan: make(chan *types.Transaction, streamBufferSize),
+	}
+	go func() {
+		defer close(sequence.txChan)
+
+		for i := uint64(0); i < numTxs; i++ {
+			tx, err := generator(signer, startingNonce+i)
+			if err != nil {
+				sequence.err = fmt.Errorf("failed to sign tx at index %d: %w", i, err)
+				return
+			}
+			select {
+			case sequence.txChan <- tx:
+			case <-ctx.Done():
+				sequence.err = ctx.Err()
+				return
+			}
 		}
-		txSequences[i] = txs
+	}()
+	return sequence
+}
+
+// GenerateTxSequences fetches the pending nonce of each of [signers] and returns a sequence of [txsPerKey] transactions for each.
+// The nonces are drawn from shared Nonces as each transaction is handed out, as InterleaveNonces does, so signers with the
+// same address never reuse a nonce.
+func GenerateTxSequences(ctx context.Context, generator CreateTx, client ethclient.Client, signers []key.Signer, txsPerKey uint64) ([]TxSequence[*types.Transaction], error) {
+	nonces, err := FetchNonces(ctx, client, signers)
+	if err != nil {
+		return nil, err
+	}
+	txSequences := make([]TxSequence[*types.Transaction], len(signers))
+	for i, signer := range signers {
+		txSequences[i] = InterleaveNonces(ctx, nonces, TxSource{
+			Generator: generator,
+			Signer:    signer,
+			NumTxs:    txsPerKey,
+		})
 	}
 	return txSequences, nil
 }
 
 type txSequence struct {
 	txChan chan *types.Transaction
+	// err is written before txChan is closed, so it is safe to read once txChan is drained.
+	err error
 }
 
 func ConvertTxSliceToSequence(txs []*types.Transaction) TxSequence[*types.Transaction] {
@@ -66,3 +160,7 @@ func ConvertTxSliceToSequence(txs []*types.Transaction) TxSequence[*types.Transa
 func (t *txSequence) Chan() <-chan *types.Transaction {
 	return t.txChan
 }
+
+func (t *txSequence) Err() error {
+	return t.err
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoncesNext(t *testing.T) {
+	var (
+		tracked   = common.Address{1}
+		untracked = common.Address{2}
+	)
+
+	tests := []struct {
+		name     string
+		calls    []common.Address
+		expected []uint64
+	}{
+		{
+			name:     "tracked address",
+			calls:    []common.Address{tracked, tracked, tracked},
+			expected: []uint64{5, 6, 7},
+		},
+		{
+			name:     "untracked address starts at 0",
+			calls:    []common.Address{untracked, untracked},
+			expected: []uint64{0, 1},
+		},
+		{
+			name:     "addresses counted independently",
+			calls:    []common.Address{tracked, untracked, tracked, untracked},
+			expected: []uint64{5, 0, 6, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			nonces := &Nonces{
+				next: map[common.Address]uint64{tracked: 5},
+			}
+			handedOut := make([]uint64, 0, len(tt.calls))
+			for _, address := range tt.calls {
+				handedOut = append(handedOut, nonces.Next(address))
+			}
+			require.Equal(tt.expected, handedOut)
+		})
+	}
+}
+
+func TestNoncesRelease(t *testing.T) {
+	require := require.New(t)
+	address := common.Address{1}
+	nonces := &Nonces{
+		next: map[common.Address]uint64{address: 5},
+	}
+
+	// The latest reservation is undone.
+	nonce := nonces.Next(address)
+	nonces.release(address, nonce)
+	require.Equal(uint64(5), nonces.Next(address))
+
+	// A reservation followed by a later one is kept, so the later nonce is not reused.
+	nonces.Next(address)
+	nonces.release(address, 5)
+	require.Equal(uint64(7), nonces.Next(address))
+}
@@ -115,6 +115,20 @@ func (f *FeeConfig) Equal(other *FeeConfig) bool {
 		utils.BigNumEqual(f.BlockGasCostStep, other.BlockGasCostStep)
 }
 
+// Copy returns a copy of [f] that does not share any of its big.Int fields.
+func (f *FeeConfig) Copy() *FeeConfig {
+	return &FeeConfig{
+		GasLimit:                 utils.BigNumCopy(f.GasLimit),
+		TargetBlockRate:          f.TargetBlockRate,
+		MinBaseFee:               utils.BigNumCopy(f.MinBaseFee),
+		TargetGas:                utils.BigNumCopy(f.TargetGas),
+		BaseFeeChangeDenominator: utils.BigNumCopy(f.BaseFeeChangeDenominator),
+		MinBlockGasCost:          utils.BigNumCopy(f.MinBlockGasCost),
+		MaxBlockGasCost:          utils.BigNumCopy(f.MaxBlockGasCost),
+		BlockGasCostStep:         utils.BigNumCopy(f.BlockGasCostStep),
+	}
+}
+
 // checkByteLens checks byte lengths against common.HashLen (32 bytes) and returns error
 func (f *FeeConfig) checkByteLens() error {
 	if isBiggerThanHashLen(f.GasLimit) {
@@ -29,6 +29,7 @@ package types
 import (
 	"math/big"
 
+	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -52,6 +53,33 @@ func (al AccessList) StorageKeys() int {
 	return sum
 }
 
+// AccessListFromTrace returns the access list of the storage slots read or written in [trace], such as the
+// accesses made by a precompile. Addresses and their storage keys are listed in the order they were first
+// accessed, and each is listed only once.
+func AccessListFromTrace(trace []contract.StateAccess) AccessList {
+	var (
+		accessList AccessList
+		indices    = make(map[common.Address]int)
+		seen       = make(map[contract.StateAccess]struct{})
+	)
+	for _, access := range trace {
+		slot := contract.StateAccess{Address: access.Address, Key: access.Key}
+		if _, ok := seen[slot]; ok {
+			continue
+		}
+		seen[slot] = struct{}{}
+
+		i, ok := indices[access.Address]
+		if !ok {
+			i = len(accessList)
+			indices[access.Address] = i
+			accessList = append(accessList, AccessTuple{Address: access.Address, StorageKeys: []common.Hash{}})
+		}
+		accessList[i].StorageKeys = append(accessList[i].StorageKeys, access.Key)
+	}
+	return accessList
+}
+
 // AccessListTx is the data of EIP-2930 access list transactions.
 type AccessListTx struct {
 	ChainID    *big.Int        // destination chain ID
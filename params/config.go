@@ -169,6 +169,9 @@ type UpgradeConfig struct {
 
 	// Config for enabling and disabling precompiles as network upgrades.
 	PrecompileUpgrades []PrecompileUpgrade `json:"precompileUpgrades,omitempty"`
+
+	// Config for overriding the gas cost of precompile functions as network upgrades.
+	PrecompileGasCosts []PrecompileGasCostUpgrade `json:"precompileGasCosts,omitempty"`
 }
 
 // AvalancheContext provides Avalanche specific context directly into the EVM.
@@ -444,6 +447,11 @@ func (c *ChainConfig) Verify() error {
 		return fmt.Errorf("invalid state upgrades: %w", err)
 	}
 
+	// Verify the precompile gas cost upgrades are internally consistent.
+	if err := c.verifyPrecompileGasCosts(); err != nil {
+		return fmt.Errorf("invalid precompile gas costs: %w", err)
+	}
+
 	return nil
 }
 
@@ -589,6 +597,11 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, height *big.Int, time
 		return err
 	}
 
+	// Check that the precompile gas costs on the new config are compatible with the existing precompile gas costs.
+	if err := c.CheckPrecompileGasCostsCompatible(newcfg.PrecompileGasCosts, time); err != nil {
+		return err
+	}
+
 	// TODO verify that the fee config is fully compatible between [c] and [newcfg].
 	return nil
 }
@@ -341,3 +341,61 @@ func TestPrecompileUpgradeUnmarshalJSON(t *testing.T) {
 	require.NoError(err)
 	require.Equal(upgradeConfig, upgradeConfig2)
 }
+
+func TestNewPrecompiles(t *testing.T) {
+	require := require.New(t)
+	admins := []common.Address{{1}}
+
+	precompiles, err := NewPrecompiles(TestChainConfig,
+		txallowlist.NewConfig(utils.NewUint64(0), admins, nil, nil),
+		nativeminter.NewConfig(utils.NewUint64(0), admins, nil, nil, nil),
+	)
+	require.NoError(err)
+
+	// The precompiles round trip through the genesis chain config.
+	config := *TestChainConfig
+	config.GenesisPrecompiles = precompiles
+	configBytes, err := json.Marshal(config)
+	require.NoError(err)
+	var config2 ChainConfig
+	require.NoError(json.Unmarshal(configBytes, &config2))
+	require.Len(config2.GenesisPrecompiles, 2)
+	require.True(config2.GenesisPrecompiles[txallowlist.ConfigKey].Equal(precompiles[txallowlist.ConfigKey]))
+	require.True(config2.GenesisPrecompiles[nativeminter.ConfigKey].Equal(precompiles[nativeminter.ConfigKey]))
+
+	_, err = NewPrecompiles(TestChainConfig,
+		txallowlist.NewConfig(utils.NewUint64(0), admins, nil, nil),
+		txallowlist.NewConfig(utils.NewUint64(0), nil, admins, nil),
+	)
+	require.ErrorContains(err, "duplicate genesis precompile config")
+
+	_, err = NewPrecompiles(TestChainConfig,
+		txallowlist.NewConfig(utils.NewUint64(0), admins, admins, nil),
+	)
+	require.ErrorContains(err, "cannot set address")
+}
+
+func TestNewPrecompileUpgrades(t *testing.T) {
+	require := require.New(t)
+	admins := []common.Address{{1}}
+
+	upgrades, err := NewPrecompileUpgrades(TestChainConfig,
+		txallowlist.NewConfig(utils.NewUint64(1), admins, nil, nil),
+		txallowlist.NewDisableConfig(utils.NewUint64(2)),
+	)
+	require.NoError(err)
+
+	upgradeBytes, err := json.Marshal(UpgradeConfig{PrecompileUpgrades: upgrades})
+	require.NoError(err)
+	var upgradeConfig UpgradeConfig
+	require.NoError(json.Unmarshal(upgradeBytes, &upgradeConfig))
+	require.Len(upgradeConfig.PrecompileUpgrades, 2)
+	for i, upgrade := range upgradeConfig.PrecompileUpgrades {
+		require.True(upgrade.Equal(upgrades[i].Config))
+	}
+
+	_, err = NewPrecompileUpgrades(TestChainConfig,
+		txallowlist.NewConfig(utils.NewUint64(1), admins, admins, nil),
+	)
+	require.ErrorContains(err, "cannot set address")
+}
@@ -0,0 +1,127 @@
+// (c) 2023 Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/utils"
+)
+
+// PrecompileGasCostUpgrade overrides the gas cost of precompile functions from
+// BlockTimestamp until the next PrecompileGasCostUpgrade.
+type PrecompileGasCostUpgrade struct {
+	BlockTimestamp *uint64 `json:"blockTimestamp,omitempty"`
+
+	// GasCosts is keyed by the precompile's config key and then the function name.
+	// Functions that are not listed keep their default cost.
+	GasCosts map[string]map[string]uint64 `json:"gasCosts"`
+}
+
+func (p *PrecompileGasCostUpgrade) Equal(other *PrecompileGasCostUpgrade) bool {
+	return reflect.DeepEqual(p, other)
+}
+
+// verifyPrecompileGasCosts checks [c.PrecompileGasCosts] is well formed:
+// - the specified blockTimestamps must monotonically increase
+// - each gas cost must be non-zero and override a function listed in the GasCosts of a registered module
+func (c *ChainConfig) verifyPrecompileGasCosts() error {
+	var previousUpgradeTimestamp *uint64
+	for i, upgrade := range c.PrecompileGasCosts {
+		upgradeTimestamp := upgrade.BlockTimestamp
+		if upgradeTimestamp == nil {
+			return fmt.Errorf("PrecompileGasCostUpgrade[%d]: config block timestamp cannot be nil", i)
+		}
+		// Verify specified timestamps are strictly monotonically increasing.
+		if previousUpgradeTimestamp != nil && *upgradeTimestamp <= *previousUpgradeTimestamp {
+			return fmt.Errorf("PrecompileGasCostUpgrade[%d]: config block timestamp (%v) <= previous timestamp (%v)", i, *upgradeTimestamp, *previousUpgradeTimestamp)
+		}
+		previousUpgradeTimestamp = upgradeTimestamp
+
+		for key, costs := range upgrade.GasCosts {
+			module, ok := modules.GetPrecompileModule(key)
+			if !ok {
+				return fmt.Errorf("PrecompileGasCostUpgrade[%d]: unknown precompile config: %s", i, key)
+			}
+			for function, cost := range costs {
+				if _, ok := module.GasCosts[function]; !ok {
+					return fmt.Errorf("PrecompileGasCostUpgrade[%d]: unknown function %s of precompile %s", i, function, key)
+				}
+				if cost == 0 {
+					return fmt.Errorf("PrecompileGasCostUpgrade[%d]: gas cost of function %s of precompile %s cannot be 0", i, function, key)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// GetPrecompileGasCost returns the gas cost of [function] of the precompile with config key [key] at
+// [timestamp], as overridden by the last PrecompileGasCostUpgrade activated at [timestamp], or
+// [defaultCost] if the function is not overridden.
+// Implements precompile.ChainConfig interface.
+func (c *ChainConfig) GetPrecompileGasCost(key string, function string, timestamp uint64, defaultCost uint64) uint64 {
+	for i := len(c.PrecompileGasCosts) - 1; i >= 0; i-- {
+		upgrade := c.PrecompileGasCosts[i]
+		if utils.IsTimestampForked(upgrade.BlockTimestamp, timestamp) {
+			if cost, ok := upgrade.GasCosts[key][function]; ok {
+				return cost
+			}
+			break
+		}
+	}
+	return defaultCost
+}
+
+// GetActivatingPrecompileGasCosts returns all precompile gas cost upgrades configured to activate
+// during the state transition from a block with timestamp [from] to a block with timestamp [to].
+func (c *ChainConfig) GetActivatingPrecompileGasCosts(from *uint64, to uint64, upgrades []PrecompileGasCostUpgrade) []PrecompileGasCostUpgrade {
+	activating := make([]PrecompileGasCostUpgrade, 0)
+	for _, upgrade := range upgrades {
+		if utils.IsForkTransition(upgrade.BlockTimestamp, from, to) {
+			activating = append(activating, upgrade)
+		}
+	}
+	return activating
+}
+
+// CheckPrecompileGasCostsCompatible checks if [gasCostUpgrades] are compatible with [c] at [headTimestamp].
+func (c *ChainConfig) CheckPrecompileGasCostsCompatible(gasCostUpgrades []PrecompileGasCostUpgrade, lastTimestamp uint64) *ConfigCompatError {
+	// All active upgrades (from nil to [lastTimestamp]) must match.
+	activeUpgrades := c.GetActivatingPrecompileGasCosts(nil, lastTimestamp, c.PrecompileGasCosts)
+	newUpgrades := c.GetActivatingPrecompileGasCosts(nil, lastTimestamp, gasCostUpgrades)
+
+	// Check activated upgrades are still present.
+	for i, upgrade := range activeUpgrades {
+		if len(newUpgrades) <= i {
+			// missing upgrade
+			return newTimestampCompatError(
+				fmt.Sprintf("missing PrecompileGasCostUpgrade[%d]", i),
+				upgrade.BlockTimestamp,
+				nil,
+			)
+		}
+		// All upgrades that have activated must be identical.
+		if !upgrade.Equal(&newUpgrades[i]) {
+			return newTimestampCompatError(
+				fmt.Sprintf("PrecompileGasCostUpgrade[%d]", i),
+				upgrade.BlockTimestamp,
+				newUpgrades[i].BlockTimestamp,
+			)
+		}
+	}
+	// then, make sure newUpgrades does not have additional upgrades
+	// that are already activated. (cannot perform retroactive upgrade)
+	if len(newUpgrades) > len(activeUpgrades) {
+		return newTimestampCompatError(
+			fmt.Sprintf("cannot retroactively enable PrecompileGasCostUpgrade[%d]", len(activeUpgrades)),
+			nil,
+			newUpgrades[len(activeUpgrades)].BlockTimestamp, // this indexes to the first element in newUpgrades after the end of activeUpgrades
+		)
+	}
+
+	return nil
+}
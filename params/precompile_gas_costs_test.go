@@ -0,0 +1,228 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPrecompileGasCosts(t *testing.T) {
+	mintCost := map[string]map[string]uint64{
+		nativeminter.ConfigKey: {"mintNativeCoin": 1},
+	}
+	tests := []struct {
+		name          string
+		upgrades      []PrecompileGasCostUpgrade
+		expectedError string
+	}{
+		{
+			name: "valid upgrade",
+			upgrades: []PrecompileGasCostUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), GasCosts: mintCost},
+				{BlockTimestamp: utils.NewUint64(2), GasCosts: nil},
+			},
+		},
+		{
+			name: "upgrade block timestamp is nil",
+			upgrades: []PrecompileGasCostUpgrade{
+				{GasCosts: mintCost},
+			},
+			expectedError: "config block timestamp cannot be nil",
+		},
+		{
+			name: "upgrade block timestamp is not strictly increasing",
+			upgrades: []PrecompileGasCostUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), GasCosts: mintCost},
+				{BlockTimestamp: utils.NewUint64(1), GasCosts: mintCost},
+			},
+			expectedError: "config block timestamp (1) <= previous timestamp (1)",
+		},
+		{
+			name: "unknown precompile",
+			upgrades: []PrecompileGasCostUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), GasCosts: map[string]map[string]uint64{"unknownConfig": {"mintNativeCoin": 1}}},
+			},
+			expectedError: "unknown precompile config: unknownConfig",
+		},
+		{
+			name: "unknown function",
+			upgrades: []PrecompileGasCostUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), GasCosts: map[string]map[string]uint64{nativeminter.ConfigKey: {"burnNativeCoin": 1}}},
+			},
+			expectedError: "unknown function burnNativeCoin of precompile contractNativeMinterConfig",
+		},
+		{
+			name: "precompile without overridable functions",
+			upgrades: []PrecompileGasCostUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), GasCosts: map[string]map[string]uint64{txallowlist.ConfigKey: {"setAdmin": 1}}},
+			},
+			expectedError: "unknown function setAdmin of precompile txAllowListConfig",
+		},
+		{
+			name: "zero gas cost",
+			upgrades: []PrecompileGasCostUpgrade{
+				{BlockTimestamp: utils.NewUint64(1), GasCosts: map[string]map[string]uint64{nativeminter.ConfigKey: {"mintNativeCoin": 0}}},
+			},
+			expectedError: "gas cost of function mintNativeCoin of precompile contractNativeMinterConfig cannot be 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			baseConfig := *TestChainConfig
+			config := &baseConfig
+			config.PrecompileGasCosts = tt.upgrades
+
+			err := config.Verify()
+			if tt.expectedError == "" {
+				require.NoError(err)
+			} else {
+				require.ErrorContains(err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestGetPrecompileGasCost(t *testing.T) {
+	require := require.New(t)
+	config := *TestChainConfig
+	config.PrecompileGasCosts = []PrecompileGasCostUpgrade{
+		{
+			BlockTimestamp: utils.NewUint64(10),
+			GasCosts:       map[string]map[string]uint64{nativeminter.ConfigKey: {"mintNativeCoin": 1}},
+		},
+		{
+			// Functions that are not listed go back to their default cost.
+			BlockTimestamp: utils.NewUint64(20),
+			GasCosts:       map[string]map[string]uint64{},
+		},
+		{
+			BlockTimestamp: utils.NewUint64(30),
+			GasCosts:       map[string]map[string]uint64{nativeminter.ConfigKey: {"mintNativeCoin": 2}},
+		},
+	}
+	require.NoError(config.Verify())
+
+	// Overrides are ignored before they activate.
+	require.Equal(uint64(30_000), config.GetPrecompileGasCost(nativeminter.ConfigKey, "mintNativeCoin", 9, 30_000))
+	require.Equal(uint64(1), config.GetPrecompileGasCost(nativeminter.ConfigKey, "mintNativeCoin", 10, 30_000))
+	require.Equal(uint64(1), config.GetPrecompileGasCost(nativeminter.ConfigKey, "mintNativeCoin", 19, 30_000))
+	require.Equal(uint64(30_000), config.GetPrecompileGasCost(nativeminter.ConfigKey, "mintNativeCoin", 20, 30_000))
+	require.Equal(uint64(2), config.GetPrecompileGasCost(nativeminter.ConfigKey, "mintNativeCoin", 30, 30_000))
+	require.Equal(uint64(30_000), config.GetPrecompileGasCost(nativeminter.ConfigKey, "other", 30, 30_000))
+	require.Equal(uint64(30_000), config.GetPrecompileGasCost(txallowlist.ConfigKey, "mintNativeCoin", 30, 30_000))
+}
+
+func TestCheckCompatiblePrecompileGasCosts(t *testing.T) {
+	chainConfig := *TestChainConfig
+	gasCosts := map[string]map[string]uint64{
+		nativeminter.ConfigKey: {"mintNativeCoin": 1},
+	}
+	differentGasCosts := map[string]map[string]uint64{
+		nativeminter.ConfigKey: {"mintNativeCoin": 2},
+	}
+
+	tests := map[string]upgradeCompatibilityTest{
+		"modify upgrade before it happens": {
+			startTimestamps: []uint64{5, 6},
+			configs: []*UpgradeConfig{
+				{
+					PrecompileGasCosts: []PrecompileGasCostUpgrade{
+						{BlockTimestamp: utils.NewUint64(7), GasCosts: gasCosts},
+					},
+				},
+				{
+					PrecompileGasCosts: []PrecompileGasCostUpgrade{
+						{BlockTimestamp: utils.NewUint64(7), GasCosts: differentGasCosts},
+					},
+				},
+			},
+		},
+		"modify upgrade after it happens not allowed": {
+			expectedErrorString: "mismatching PrecompileGasCostUpgrade[1]",
+			startTimestamps:     []uint64{5, 8},
+			configs: []*UpgradeConfig{
+				{
+					PrecompileGasCosts: []PrecompileGasCostUpgrade{
+						{BlockTimestamp: utils.NewUint64(6), GasCosts: gasCosts},
+						{BlockTimestamp: utils.NewUint64(7), GasCosts: gasCosts},
+					},
+				},
+				{
+					PrecompileGasCosts: []PrecompileGasCostUpgrade{
+						{BlockTimestamp: utils.NewUint64(6), GasCosts: gasCosts},
+						{BlockTimestamp: utils.NewUint64(7), GasCosts: differentGasCosts},
+					},
+				},
+			},
+		},
+		"cancel upgrade after it happens not allowed": {
+			expectedErrorString: "missing PrecompileGasCostUpgrade[0]",
+			startTimestamps:     []uint64{5, 8},
+			configs: []*UpgradeConfig{
+				{
+					PrecompileGasCosts: []PrecompileGasCostUpgrade{
+						{BlockTimestamp: utils.NewUint64(6), GasCosts: gasCosts},
+					},
+				},
+				{},
+			},
+		},
+		"retroactively enabling upgrades is not allowed": {
+			expectedErrorString: "cannot retroactively enable PrecompileGasCostUpgrade[0]",
+			startTimestamps:     []uint64{6},
+			configs: []*UpgradeConfig{
+				{
+					PrecompileGasCosts: []PrecompileGasCostUpgrade{
+						{BlockTimestamp: utils.NewUint64(5), GasCosts: gasCosts},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.run(t, chainConfig)
+		})
+	}
+}
+
+func TestUnmarshalPrecompileGasCostsJSON(t *testing.T) {
+	jsonBytes := []byte(
+		`{
+			"precompileGasCosts": [
+				{
+					"blockTimestamp": 1677608400,
+					"gasCosts": {
+						"contractNativeMinterConfig": {
+							"mintNativeCoin": 60000
+						}
+					}
+				}
+			]
+		}`,
+	)
+
+	upgradeConfig := UpgradeConfig{
+		PrecompileGasCosts: []PrecompileGasCostUpgrade{
+			{
+				BlockTimestamp: utils.NewUint64(1677608400),
+				GasCosts: map[string]map[string]uint64{
+					nativeminter.ConfigKey: {"mintNativeCoin": 60_000},
+				},
+			},
+		},
+	}
+	var unmarshaledConfig UpgradeConfig
+	err := json.Unmarshal(jsonBytes, &unmarshaledConfig)
+	require.NoError(t, err)
+	require.Equal(t, upgradeConfig, unmarshaledConfig)
+}
@@ -61,6 +61,20 @@ func (u *PrecompileUpgrade) MarshalJSON() ([]byte, error) {
 	return json.Marshal(res)
 }
 
+// NewPrecompileUpgrades returns the precompile upgrades activating [configs] in the given order.
+// Each config is verified against [chainConfig]. Ordering constraints between the upgrades
+// are checked when the resulting UpgradeConfig is verified by the chain.
+func NewPrecompileUpgrades(chainConfig precompileconfig.ChainConfig, configs ...precompileconfig.Config) ([]PrecompileUpgrade, error) {
+	upgrades := make([]PrecompileUpgrade, 0, len(configs))
+	for i, config := range configs {
+		if err := config.Verify(chainConfig); err != nil {
+			return nil, fmt.Errorf("invalid precompile upgrade %s at index %d: %w", config.Key(), i, err)
+		}
+		upgrades = append(upgrades, PrecompileUpgrade{Config: config})
+	}
+	return upgrades, nil
+}
+
 // verifyPrecompileUpgrades checks [c.PrecompileUpgrades] is well formed:
 //   - [upgrades] must specify exactly one key per PrecompileUpgrade
 //   - the specified blockTimestamps must monotonically increase
@@ -5,6 +5,7 @@ package params
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -34,3 +35,21 @@ func (ccp *Precompiles) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// NewPrecompiles returns the genesis precompiles enabling [configs], keyed by each config's Key().
+// Each config is verified against [chainConfig], and a key may only be configured once.
+// The result can be set as GenesisPrecompiles, or marshalled directly into the genesis "config" section.
+func NewPrecompiles(chainConfig precompileconfig.ChainConfig, configs ...precompileconfig.Config) (Precompiles, error) {
+	precompiles := make(Precompiles, len(configs))
+	for _, config := range configs {
+		key := config.Key()
+		if _, ok := precompiles[key]; ok {
+			return nil, fmt.Errorf("duplicate genesis precompile config: %s", key)
+		}
+		if err := config.Verify(chainConfig); err != nil {
+			return nil, fmt.Errorf("invalid genesis precompile config %s: %w", key, err)
+		}
+		precompiles[key] = config
+	}
+	return precompiles, nil
+}
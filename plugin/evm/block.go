@@ -19,11 +19,13 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/warp"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 )
 
 var (
@@ -113,7 +115,7 @@ func (b *Block) handlePrecompileAccept(rules *params.Rules, sharedMemoryWriter *
 	acceptCtx := &precompileconfig.AcceptContext{
 		SnowCtx:      b.vm.ctx,
 		SharedMemory: sharedMemoryWriter,
-		Warp:         b.vm.warpBackend,
+		Warp:         &warpMessageWriter{backend: b.vm.warpBackend},
 	}
 	for _, receipt := range receipts {
 		for logIdx, log := range receipt.Logs {
@@ -130,6 +132,23 @@ func (b *Block) handlePrecompileAccept(rules *params.Rules, sharedMemoryWriter *
 	return nil
 }
 
+// warpMessageWriter adds the warp messages of accepted blocks to the warp backend.
+// A message rejected by the backend is still part of the accepted block, so the rejection
+// is logged at Warn and counted by the backend's warp_backend_message_rejected metric
+// instead of failing block acceptance.
+type warpMessageWriter struct {
+	backend warp.Backend
+}
+
+func (w *warpMessageWriter) AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage) error {
+	err := w.backend.AddMessage(unsignedMessage)
+	if errors.Is(err, warp.ErrMessageTooLarge) || errors.Is(err, warp.ErrInvalidMessage) {
+		log.Warn("Not signing accepted warp message rejected by the warp backend", "messageID", unsignedMessage.ID(), "err", err)
+		return nil
+	}
+	return err
+}
+
 // Reject implements the snowman.Block interface
 func (b *Block) Reject(context.Context) error {
 	b.status = choices.Rejected
@@ -124,6 +124,17 @@ type Config struct {
 	PopulateMissingTries            *uint64 `json:"populate-missing-tries,omitempty"`   // Sets the starting point for re-populating missing tries. Disables re-generation if nil.
 	PopulateMissingTriesParallelism int     `json:"populate-missing-tries-parallelism"` // Number of concurrent readers to use when re-populating missing tries on startup.
 	PruneWarpDB                     bool    `json:"prune-warp-db-enabled"`              // Determines if the warpDB should be cleared on startup
+	WarpSigningConcurrency          int     `json:"warp-signing-concurrency"`           // Number of background workers signing warp messages. Messages are signed synchronously if zero.
+	PersistWarpSignatures           bool    `json:"persist-warp-signatures"`            // Persists warp message signatures in the warpDB so they are not re-signed after a restart
+	WarpMaxMessageSize              int     `json:"warp-max-message-size"`              // Maximum size in bytes of an accepted warp message to sign. Larger messages are not signed. Unlimited if zero.
+	// WarpMissCacheTTL is how long a warp message missing from the warpDB is reported missing without reading the
+	// warpDB again, so relayers polling for a message that has not been accepted yet do not each read it. Disabled if zero.
+	WarpMissCacheTTL Duration `json:"warp-miss-cache-ttl"`
+	// WarpSignAttempts is the number of times signing an accepted warp message is attempted before giving up,
+	// waiting WarpSignRetryDelay between attempts. If signing fails synchronously, the message is not kept in
+	// the warpDB and block acceptance fails. Signed once if zero.
+	WarpSignAttempts   int      `json:"warp-sign-attempts"`
+	WarpSignRetryDelay Duration `json:"warp-sign-retry-delay"`
 
 	// Metric Settings
 	MetricsExpensiveEnabled bool `json:"metrics-expensive-enabled"` // Debug-level metrics that might impact runtime performance
@@ -3,12 +3,20 @@
 
 package evm
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // Health returns nil if this chain is healthy.
 // Also returns details, which should be one of:
 // string, []byte, map[string]string
-func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
-	// TODO perform actual health check
+func (vm *VM) HealthCheck(ctx context.Context) (interface{}, error) {
+	// TODO perform actual health check of the chain
+	if vm.warpBackend != nil {
+		if err := vm.warpBackend.HealthCheck(ctx); err != nil {
+			return nil, fmt.Errorf("warp backend is unhealthy: %w", err)
+		}
+	}
 	return nil, nil
 }
@@ -467,7 +467,20 @@ func (vm *VM) Initialize(
 	vm.client = peer.NewNetworkClient(vm.Network)
 
 	// initialize warp backend
-	vm.warpBackend = warp.NewBackend(vm.ctx.NetworkID, vm.ctx.ChainID, vm.ctx.WarpSigner, vm, vm.warpDB, warpSignatureCacheSize)
+	vm.warpBackend = warp.NewBackend(vm.warpDB, warp.BackendConfig{
+		NetworkID:          vm.ctx.NetworkID,
+		SourceChainID:      vm.ctx.ChainID,
+		Signer:             vm.ctx.WarpSigner,
+		BlockClient:        vm,
+		CacheSize:          warpSignatureCacheSize,
+		SigningConcurrency: vm.config.WarpSigningConcurrency,
+		PublicKey:          vm.ctx.PublicKey,
+		PersistSignatures:  vm.config.PersistWarpSignatures,
+		MaxMessageSize:     vm.config.WarpMaxMessageSize,
+		MissCacheTTL:       vm.config.WarpMissCacheTTL.Duration,
+		SignAttempts:       vm.config.WarpSignAttempts,
+		SignRetryDelay:     vm.config.WarpSignRetryDelay.Duration,
+	})
 
 	// clear warpdb on initialization if config enabled
 	if vm.config.PruneWarpDB {
@@ -756,6 +769,9 @@ func (vm *VM) Shutdown(context.Context) error {
 	close(vm.shutdownChan)
 	vm.eth.Stop()
 	log.Info("Ethereum backend stop completed")
+	if vm.warpBackend != nil {
+		vm.warpBackend.Close()
+	}
 	vm.shutdownWg.Wait()
 	log.Info("Subnet-EVM Shutdown completed")
 	return nil
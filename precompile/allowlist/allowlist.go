@@ -4,7 +4,6 @@
 package allowlist
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/ava-labs/subnet-evm/precompile/contract"
@@ -27,6 +26,10 @@ const (
 	ReadAllowListGasCost   = contract.ReadGasCostPerSlot
 
 	allowListInputLen = common.HashLength
+
+	// allowListStoragePrefix is the storage key prefix of allow list roles. It is 0, so the key
+	// of an address is address.Hash() as it has always been.
+	allowListStoragePrefix byte = 0
 )
 
 var (
@@ -45,14 +48,14 @@ var (
 	setNoneSignature       = contract.CalculateFunctionSelector("setNone(address)")
 	readAllowListSignature = contract.CalculateFunctionSelector("readAllowList(address)")
 	// Error returned when an invalid write is attempted
-	ErrCannotModifyAllowList = errors.New("cannot modify allow list")
+	ErrCannotModifyAllowList = contract.NewCallerNotAllowedError("cannot modify allow list")
 )
 
 // GetAllowListStatus returns the allow list role of [address] for the precompile
 // at [precompileAddr]
 func GetAllowListStatus(state contract.StateDB, precompileAddr common.Address, address common.Address) Role {
 	// Generate the state key for [address]
-	addressKey := address.Hash()
+	addressKey := contract.StorageKey(allowListStoragePrefix, address)
 	return Role(state.GetState(precompileAddr, addressKey))
 }
 
@@ -61,12 +64,13 @@ func GetAllowListStatus(state contract.StateDB, precompileAddr common.Address, a
 // assumes [role] has already been verified as valid.
 func SetAllowListRole(stateDB contract.StateDB, precompileAddr, address common.Address, role Role) {
 	// Generate the state key for [address]
-	addressKey := address.Hash()
+	addressKey := contract.StorageKey(allowListStoragePrefix, address)
 	// Assign [role] to the address
 	// This stores the [role] in the contract storage with address [precompileAddr]
 	// and [addressKey] hash. It means that any reusage of the [addressKey] for different value
 	// conflicts with the same slot [role] is stored.
-	// Precompile implementations must use a different key than [addressKey]
+	// Precompile implementations must use a different key than [addressKey], such as
+	// a contract.StorageKey with a non-zero prefix.
 	stateDB.SetState(precompileAddr, addressKey, common.Hash(role))
 }
 
@@ -111,10 +115,13 @@ func createAllowListRoleSetter(precompileAddr common.Address, role Role) contrac
 		}
 
 		if len(input) != allowListInputLen {
-			return nil, remainingGas, fmt.Errorf("invalid input length for modifying allow list: %d", len(input))
+			return nil, remainingGas, fmt.Errorf("%w for modifying allow list: %d", contract.ErrInvalidInputLength, len(input))
 		}
 
-		modifyAddress := common.BytesToAddress(input)
+		modifyAddress, err := contract.UnpackAddress(input, 0)
+		if err != nil {
+			return nil, remainingGas, err
+		}
 
 		if readOnly {
 			return nil, remainingGas, vmerrs.ErrWriteProtection
@@ -145,10 +152,13 @@ func createReadAllowList(precompileAddr common.Address) contract.RunStatefulPrec
 		}
 
 		if len(input) != allowListInputLen {
-			return nil, remainingGas, fmt.Errorf("invalid input length for read allow list: %d", len(input))
+			return nil, remainingGas, fmt.Errorf("%w for read allow list: %d", contract.ErrInvalidInputLength, len(input))
 		}
 
-		readAddress := common.BytesToAddress(input)
+		readAddress, err := contract.UnpackAddress(input, 0)
+		if err != nil {
+			return nil, remainingGas, err
+		}
 		role := GetAllowListStatus(evm.GetStateDB(), precompileAddr, readAddress)
 		roleBytes := common.Hash(role).Bytes()
 		return roleBytes, remainingGas, nil
@@ -7,10 +7,13 @@ import (
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -39,6 +42,13 @@ func (d *dummyConfig) Equal(cfg precompileconfig.Config) bool {
 	return d.AllowListConfig.Equal(&other.AllowListConfig)
 }
 
+func (d *dummyConfig) Copy() precompileconfig.Config {
+	return &dummyConfig{
+		Upgrade:         d.Upgrade.Copy(),
+		AllowListConfig: d.AllowListConfig.Copy(),
+	}
+}
+
 type dummyConfigurator struct{}
 
 func (d *dummyConfigurator) MakeConfig() precompileconfig.Config {
@@ -74,3 +84,104 @@ func BenchmarkAllowList(b *testing.B) {
 	}
 	BenchPrecompileWithAllowList(b, dummyModule, state.NewTestStateDB, nil)
 }
+
+func TestAllowListStorageAccesses(t *testing.T) {
+	test := testutils.PrecompileTest{
+		Caller:     TestAdminAddr,
+		BeforeHook: SetDefaultRoles(dummyAddr),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: ModifyAllowListGasCost,
+		ExpectedRes: []byte{},
+		AccessHook: func(t testing.TB, accesses []testutils.StateAccess) {
+			// The precompile only touches the slots of the caller and the modified address.
+			require.Equal(t, []testutils.StateAccess{
+				{Address: dummyAddr, Key: TestAdminAddr.Hash()},
+				{Address: dummyAddr, Key: TestNoRoleAddr.Hash()},
+				{Address: dummyAddr, Key: TestNoRoleAddr.Hash(), Write: true},
+			}, accesses)
+		},
+	}
+	test.Run(t, testModule, state.NewTestStateDB(t))
+}
+
+func TestAllowListAccessListFromTrace(t *testing.T) {
+	test := testutils.PrecompileTest{
+		Caller:     TestAdminAddr,
+		BeforeHook: SetDefaultRoles(dummyAddr),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: ModifyAllowListGasCost,
+		ExpectedRes: []byte{},
+		Steps: []testutils.PrecompileStep{
+			{
+				Input:       PackReadAllowList(TestEnabledAddr),
+				SuppliedGas: ReadAllowListGasCost,
+				ReadOnly:    true,
+				ExpectedRes: common.Hash(EnabledRole).Bytes(),
+			},
+			{
+				Input:       PackReadAllowList(TestNoRoleAddr),
+				SuppliedGas: ReadAllowListGasCost,
+				ReadOnly:    true,
+				ExpectedRes: common.Hash(EnabledRole).Bytes(),
+			},
+		},
+		AccessHook: func(t testing.TB, accesses []testutils.StateAccess) {
+			// Each slot is listed once, in the order it was first accessed.
+			require.Equal(t, types.AccessList{
+				{
+					Address:     dummyAddr,
+					StorageKeys: []common.Hash{TestAdminAddr.Hash(), TestNoRoleAddr.Hash(), TestEnabledAddr.Hash()},
+				},
+			}, types.AccessListFromTrace(accesses))
+		},
+	}
+	test.Run(t, testModule, state.NewTestStateDB(t))
+}
+
+func TestAllowListMultipleCallers(t *testing.T) {
+	grantAdmin, err := PackModifyAllowList(TestNoRoleAddr, AdminRole)
+	require.NoError(t, err)
+	grantEnabled, err := PackModifyAllowList(TestManagerAddr, EnabledRole)
+	require.NoError(t, err)
+
+	noRoleAddr := TestNoRoleAddr
+	test := testutils.PrecompileTest{
+		Caller:     TestAdminAddr,
+		BeforeHook: SetDefaultRoles(dummyAddr),
+		Steps: []testutils.PrecompileStep{
+			{
+				// The address cannot modify the allow list before it is granted a role.
+				Caller:      &noRoleAddr,
+				Input:       grantEnabled,
+				SuppliedGas: ModifyAllowListGasCost,
+				ExpectedErr: ErrCannotModifyAllowList.Error(),
+			},
+			{
+				Input:       grantAdmin,
+				SuppliedGas: ModifyAllowListGasCost,
+				ExpectedRes: []byte{},
+			},
+			{
+				Caller:      &noRoleAddr,
+				Input:       grantEnabled,
+				SuppliedGas: ModifyAllowListGasCost,
+				ExpectedRes: []byte{},
+			},
+		},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, AdminRole, GetAllowListStatus(state, dummyAddr, TestNoRoleAddr))
+			require.Equal(t, EnabledRole, GetAllowListStatus(state, dummyAddr, TestManagerAddr))
+		},
+	}
+	test.Run(t, testModule, state.NewTestStateDB(t))
+}
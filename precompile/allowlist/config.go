@@ -37,7 +37,33 @@ func (c *AllowListConfig) Configure(chainConfig precompileconfig.ChainConfig, pr
 	return nil
 }
 
-// Equal returns true iff [other] has the same admins in the same order in its allow list.
+// Members returns the role of every address in the config. If an address is listed under
+// multiple roles, the role it ends up with after Configure is returned.
+func (c *AllowListConfig) Members() map[common.Address]Role {
+	members := make(map[common.Address]Role, len(c.AdminAddresses)+len(c.ManagerAddresses)+len(c.EnabledAddresses))
+	// Mirror the order roles are set in Configure.
+	for _, enabledAddr := range c.EnabledAddresses {
+		members[enabledAddr] = EnabledRole
+	}
+	for _, adminAddr := range c.AdminAddresses {
+		members[adminAddr] = AdminRole
+	}
+	for _, managerAddr := range c.ManagerAddresses {
+		members[managerAddr] = ManagerRole
+	}
+	return members
+}
+
+// GetRole returns the role [addr] is configured with, or NoRole if it is not in the config.
+func (c *AllowListConfig) GetRole(addr common.Address) Role {
+	role, ok := c.Members()[addr]
+	if !ok {
+		return NoRole
+	}
+	return role
+}
+
+// Equal returns true iff [other] has the same addresses for each role in its allow list, in any order.
 func (c *AllowListConfig) Equal(other *AllowListConfig) bool {
 	if other == nil {
 		return false
@@ -48,15 +74,38 @@ func (c *AllowListConfig) Equal(other *AllowListConfig) bool {
 		areEqualAddressLists(c.EnabledAddresses, other.EnabledAddresses)
 }
 
-// areEqualAddressLists returns true iff [a] and [b] have the same addresses in the same order.
+// Copy returns a copy of [c] that does not share its address lists.
+func (c *AllowListConfig) Copy() AllowListConfig {
+	return AllowListConfig{
+		AdminAddresses:   copyAddressList(c.AdminAddresses),
+		ManagerAddresses: copyAddressList(c.ManagerAddresses),
+		EnabledAddresses: copyAddressList(c.EnabledAddresses),
+	}
+}
+
+// copyAddressList returns a copy of [addresses], preserving a nil list as nil.
+func copyAddressList(addresses []common.Address) []common.Address {
+	if addresses == nil {
+		return nil
+	}
+	return append(make([]common.Address, 0, len(addresses)), addresses...)
+}
+
+// areEqualAddressLists returns true iff [current] and [other] have the same addresses, with the
+// same multiplicity, regardless of order.
 func areEqualAddressLists(current []common.Address, other []common.Address) bool {
 	if len(current) != len(other) {
 		return false
 	}
-	for i, address := range current {
-		if address != other[i] {
+	counts := make(map[common.Address]int, len(current))
+	for _, address := range current {
+		counts[address]++
+	}
+	for _, address := range other {
+		if counts[address] == 0 {
 			return false
 		}
+		counts[address]--
 	}
 	return true
 }
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 )
 
 var testModule = modules.Module{
@@ -23,3 +25,44 @@ func TestVerifyAllowlist(t *testing.T) {
 func TestEqualAllowList(t *testing.T) {
 	EqualPrecompileWithAllowListTests(t, testModule, nil)
 }
+
+func TestAllowListConfigMembers(t *testing.T) {
+	require := require.New(t)
+	adminAddr := common.HexToAddress("0x0000000000000000000000000000000000000011")
+	managerAddr := common.HexToAddress("0x0000000000000000000000000000000000000022")
+	enabledAddr := common.HexToAddress("0x0000000000000000000000000000000000000033")
+	noRoleAddr := common.HexToAddress("0x0000000000000000000000000000000000000044")
+
+	config := &AllowListConfig{
+		AdminAddresses:   []common.Address{adminAddr},
+		ManagerAddresses: []common.Address{managerAddr},
+		EnabledAddresses: []common.Address{enabledAddr},
+	}
+	require.Equal(map[common.Address]Role{
+		adminAddr:   AdminRole,
+		managerAddr: ManagerRole,
+		enabledAddr: EnabledRole,
+	}, config.Members())
+	require.Equal(AdminRole, config.GetRole(adminAddr))
+	require.Equal(ManagerRole, config.GetRole(managerAddr))
+	require.Equal(EnabledRole, config.GetRole(enabledAddr))
+	require.Equal(NoRole, config.GetRole(noRoleAddr))
+}
+
+func TestAllowListConfigCopy(t *testing.T) {
+	require := require.New(t)
+	config := &AllowListConfig{
+		AdminAddresses:   []common.Address{{1}},
+		EnabledAddresses: []common.Address{{2}},
+	}
+
+	copied := config.Copy()
+	require.True(config.Equal(&copied))
+	require.Nil(copied.ManagerAddresses)
+
+	// Mutating the copy must not affect the original.
+	copied.AdminAddresses[0] = common.Address{3}
+	copied.EnabledAddresses = append(copied.EnabledAddresses[:0], common.Address{4})
+	require.Equal([]common.Address{{1}}, config.AdminAddresses)
+	require.Equal([]common.Address{{2}}, config.EnabledAddresses)
+}
@@ -158,11 +158,9 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 		"set manager from no role before activation": {
 			Caller:     TestNoRoleAddr,
 			BeforeHook: SetDefaultRoles(contractAddress),
-			ChainConfig: func() precompileconfig.ChainConfig {
-				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+			ConfigureChainConfig: func(config *precompileconfig.MockChainConfig) {
 				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
-				return config
-			}(),
+			},
 			InputFn: func(t testing.TB) []byte {
 				input, err := PackModifyAllowList(TestNoRoleAddr, ManagerRole)
 				require.NoError(t, err)
@@ -194,11 +192,9 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 		"set manager from enabled role before activation": {
 			Caller:     TestEnabledAddr,
 			BeforeHook: SetDefaultRoles(contractAddress),
-			ChainConfig: func() precompileconfig.ChainConfig {
-				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+			ConfigureChainConfig: func(config *precompileconfig.MockChainConfig) {
 				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
-				return config
-			}(),
+			},
 			InputFn: func(t testing.TB) []byte {
 				input, err := PackModifyAllowList(TestNoRoleAddr, ManagerRole)
 				require.NoError(t, err)
@@ -236,11 +232,9 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 
 				return input
 			},
-			ChainConfig: func() precompileconfig.ChainConfig {
-				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+			ConfigureChainConfig: func(config *precompileconfig.MockChainConfig) {
 				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
-				return config
-			}(),
+			},
 			SuppliedGas: 0,
 			ReadOnly:    false,
 			ExpectedErr: "invalid non-activated function selector",
@@ -567,7 +561,7 @@ func RunPrecompileWithAllowListTests(t *testing.T, module modules.Module, newSta
 		tests[name] = test
 	}
 
-	testutils.RunPrecompileTests(t, module, newStateDB, tests)
+	testutils.RunPrecompileTests(t, module, newStateDB, tests, false)
 }
 
 func BenchPrecompileWithAllowList(b *testing.B, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, contractTests map[string]testutils.PrecompileTest) {
@@ -201,6 +201,19 @@ func AllowListConfigEqualTests(t testing.TB, module modules.Module) map[string]t
 			}),
 			Expected: true,
 		},
+		"allowlist same config different order": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses:   []common.Address{TestAdminAddr, {3}},
+				ManagerAddresses: []common.Address{TestManagerAddr, {4}},
+				EnabledAddresses: []common.Address{TestEnabledAddr, {5}},
+			}),
+			Other: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses:   []common.Address{{3}, TestAdminAddr},
+				ManagerAddresses: []common.Address{{4}, TestManagerAddr},
+				EnabledAddresses: []common.Address{{5}, TestEnabledAddr},
+			}),
+			Expected: true,
+		},
 	}
 }
 
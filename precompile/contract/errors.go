@@ -0,0 +1,36 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import "errors"
+
+// Common precompile errors, so callers and tests can check for them with errors.Is.
+// Running out of gas and modifying state in a read only call are reported with
+// vmerrs.ErrOutOfGas and vmerrs.ErrWriteProtection.
+var (
+	// ErrInvalidInputLength is returned when the input to a precompile function has an unexpected length.
+	ErrInvalidInputLength = errors.New("invalid input length")
+	// ErrCallerNotAllowed is returned when the caller does not have the role required to call a precompile function.
+	ErrCallerNotAllowed = errors.New("caller not allowed")
+)
+
+var _ error = (*callerNotAllowedError)(nil)
+
+type callerNotAllowedError struct {
+	msg string
+}
+
+// NewCallerNotAllowedError returns an error with [msg] that matches ErrCallerNotAllowed with errors.Is.
+// Precompiles use it to keep their own error message while sharing the sentinel.
+func NewCallerNotAllowedError(msg string) error {
+	return &callerNotAllowedError{msg: msg}
+}
+
+func (e *callerNotAllowedError) Error() string {
+	return e.msg
+}
+
+func (e *callerNotAllowedError) Is(target error) bool {
+	return target == ErrCallerNotAllowed
+}
@@ -0,0 +1,24 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallerNotAllowedError(t *testing.T) {
+	errCannotCall := NewCallerNotAllowedError("non-enabled cannot call")
+	err := fmt.Errorf("%w: %s", errCannotCall, "0x01")
+
+	require.ErrorIs(t, err, errCannotCall)
+	require.ErrorIs(t, err, ErrCallerNotAllowed)
+	require.NotErrorIs(t, err, NewCallerNotAllowedError("non-enabled cannot call"))
+	require.NotErrorIs(t, err, ErrInvalidInputLength)
+	require.Equal(t, "non-enabled cannot call: 0x01", err.Error())
+	require.False(t, errors.Is(ErrCallerNotAllowed, errCannotCall))
+}
@@ -46,6 +46,14 @@ type StateDB interface {
 	RevertToSnapshot(int)
 }
 
+// StateAccess is a read or write of a storage slot in a StateDB.
+// A sequence of StateAccess is a trace of the storage touched by a precompile.
+type StateAccess struct {
+	Address common.Address
+	Key     common.Hash
+	Write   bool
+}
+
 // AccessibleState defines the interface exposed to stateful precompile contracts
 type AccessibleState interface {
 	GetStateDB() StateDB
@@ -0,0 +1,278 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ StateDB = (*OverlayStateDB)(nil)
+
+// overlayAccount is the copy of an account held by an OverlayStateDB once it has been written to.
+type overlayAccount struct {
+	exists   bool
+	suicided bool
+	nonce    uint64
+	balance  *big.Int
+	storage  map[common.Hash]common.Hash
+	// reset is true if the account was recreated or removed, so storage missing
+	// from [storage] is empty rather than read from the base state.
+	reset bool
+}
+
+// overlayLog is a log added to an OverlayStateDB.
+type overlayLog struct {
+	addr        common.Address
+	topics      []common.Hash
+	data        []byte
+	blockNumber uint64
+}
+
+// OverlayStateDB is a copy-on-write view of a StateDB.
+type OverlayStateDB struct {
+	base       StateDB
+	accounts   map[common.Address]*overlayAccount
+	logs       []overlayLog
+	predicates map[common.Address][][]byte
+	// journal holds the functions undoing each write, in the order the writes were made.
+	journal []func()
+	// ops holds the functions applying each write to the base state, in the order the writes were made.
+	ops []func(base StateDB)
+}
+
+// NewOverlayStateDB returns a StateDB that reads through to [base] but keeps every write in memory,
+// so that a precompile can be executed against it and its effects discarded by dropping the overlay,
+// as when simulating a call for eth_call or gas estimation. [base] is only written to by Commit.
+// Finalise only removes the accounts that were suicided in the overlay.
+func NewOverlayStateDB(base StateDB) *OverlayStateDB {
+	o := &OverlayStateDB{base: base}
+	o.reset()
+	return o
+}
+
+// reset discards every write held by the overlay.
+func (o *OverlayStateDB) reset() {
+	o.accounts = make(map[common.Address]*overlayAccount)
+	o.logs = nil
+	o.predicates = make(map[common.Address][][]byte)
+	o.journal = nil
+	o.ops = nil
+}
+
+// Commit applies the writes held by the overlay to the base state in the order they were made, including
+// its logs and Finalise calls, and then discards them, so the overlay reads through to the updated base state.
+// Writes reverted with RevertToSnapshot are not applied.
+func (o *OverlayStateDB) Commit() {
+	for _, op := range o.ops {
+		op(o.base)
+	}
+	o.reset()
+}
+
+// addOp records [op] to be applied to the base state on Commit, unless the write is reverted.
+func (o *OverlayStateDB) addOp(op func(base StateDB)) {
+	n := len(o.ops)
+	o.journal = append(o.journal, func() { o.ops = o.ops[:n] })
+	o.ops = append(o.ops, op)
+}
+
+// getAccount returns the overlay copy of [addr], loading it from the base state if it has not been written to yet.
+func (o *OverlayStateDB) getAccount(addr common.Address) *overlayAccount {
+	if account, ok := o.accounts[addr]; ok {
+		return account
+	}
+	account := &overlayAccount{
+		exists:  o.base.Exist(addr),
+		nonce:   o.base.GetNonce(addr),
+		balance: new(big.Int).Set(o.base.GetBalance(addr)),
+		storage: make(map[common.Hash]common.Hash),
+	}
+	o.accounts[addr] = account
+	return account
+}
+
+// updateAccount applies [update] to the overlay copy of [addr] and journals the previous account fields.
+// [update] must replace the storage map rather than modify it.
+func (o *OverlayStateDB) updateAccount(addr common.Address, update func(account *overlayAccount)) {
+	account := o.getAccount(addr)
+	prev := *account
+	o.journal = append(o.journal, func() { *account = prev })
+	update(account)
+}
+
+func (o *OverlayStateDB) GetState(addr common.Address, key common.Hash) common.Hash {
+	account, ok := o.accounts[addr]
+	if !ok {
+		return o.base.GetState(addr, key)
+	}
+	if value, ok := account.storage[key]; ok {
+		return value
+	}
+	if account.reset {
+		return common.Hash{}
+	}
+	return o.base.GetState(addr, key)
+}
+
+func (o *OverlayStateDB) SetState(addr common.Address, key common.Hash, value common.Hash) {
+	o.updateAccount(addr, func(account *overlayAccount) { account.exists = true })
+	account := o.accounts[addr]
+	prev, ok := account.storage[key]
+	o.journal = append(o.journal, func() {
+		if ok {
+			account.storage[key] = prev
+		} else {
+			delete(account.storage, key)
+		}
+	})
+	account.storage[key] = value
+	o.addOp(func(base StateDB) { base.SetState(addr, key, value) })
+}
+
+func (o *OverlayStateDB) SetNonce(addr common.Address, nonce uint64) {
+	o.updateAccount(addr, func(account *overlayAccount) {
+		account.exists = true
+		account.nonce = nonce
+	})
+	o.addOp(func(base StateDB) { base.SetNonce(addr, nonce) })
+}
+
+func (o *OverlayStateDB) GetNonce(addr common.Address) uint64 {
+	if account, ok := o.accounts[addr]; ok {
+		return account.nonce
+	}
+	return o.base.GetNonce(addr)
+}
+
+func (o *OverlayStateDB) GetBalance(addr common.Address) *big.Int {
+	if account, ok := o.accounts[addr]; ok {
+		return account.balance
+	}
+	return o.base.GetBalance(addr)
+}
+
+func (o *OverlayStateDB) AddBalance(addr common.Address, amount *big.Int) {
+	amount = new(big.Int).Set(amount)
+	o.updateAccount(addr, func(account *overlayAccount) {
+		account.exists = true
+		account.balance = new(big.Int).Add(account.balance, amount)
+	})
+	o.addOp(func(base StateDB) { base.AddBalance(addr, amount) })
+}
+
+// CreateAccount creates [addr] with empty storage and a zero nonce, keeping its balance.
+func (o *OverlayStateDB) CreateAccount(addr common.Address) {
+	o.updateAccount(addr, func(account *overlayAccount) {
+		*account = overlayAccount{
+			exists:  true,
+			balance: account.balance,
+			storage: make(map[common.Hash]common.Hash),
+			reset:   true,
+		}
+	})
+	o.addOp(func(base StateDB) { base.CreateAccount(addr) })
+}
+
+func (o *OverlayStateDB) Exist(addr common.Address) bool {
+	if account, ok := o.accounts[addr]; ok {
+		return account.exists
+	}
+	return o.base.Exist(addr)
+}
+
+func (o *OverlayStateDB) AddLog(addr common.Address, topics []common.Hash, data []byte, blockNumber uint64) {
+	log := overlayLog{
+		addr:        addr,
+		topics:      append([]common.Hash(nil), topics...),
+		data:        common.CopyBytes(data),
+		blockNumber: blockNumber,
+	}
+	o.journal = append(o.journal, func() { o.logs = o.logs[:len(o.logs)-1] })
+	o.logs = append(o.logs, log)
+	o.addOp(func(base StateDB) { base.AddLog(log.addr, log.topics, log.data, log.blockNumber) })
+}
+
+// GetLogData returns the log data of the base state followed by the log data added to the overlay.
+func (o *OverlayStateDB) GetLogData() [][]byte {
+	logData := o.base.GetLogData()
+	for _, log := range o.logs {
+		logData = append(logData, common.CopyBytes(log.data))
+	}
+	return logData
+}
+
+func (o *OverlayStateDB) GetPredicateStorageSlots(address common.Address, index int) ([]byte, bool) {
+	predicates, ok := o.predicates[address]
+	if !ok {
+		return o.base.GetPredicateStorageSlots(address, index)
+	}
+	if index >= len(predicates) {
+		return nil, false
+	}
+	return predicates[index], true
+}
+
+func (o *OverlayStateDB) SetPredicateStorageSlots(address common.Address, predicates [][]byte) {
+	prev, ok := o.predicates[address]
+	o.journal = append(o.journal, func() {
+		if ok {
+			o.predicates[address] = prev
+		} else {
+			delete(o.predicates, address)
+		}
+	})
+	o.predicates[address] = predicates
+	o.addOp(func(base StateDB) { base.SetPredicateStorageSlots(address, predicates) })
+}
+
+func (o *OverlayStateDB) GetTxHash() common.Hash {
+	return o.base.GetTxHash()
+}
+
+// Suicide marks [addr] to be removed on Finalise and clears its balance.
+// Returns false if [addr] does not exist.
+func (o *OverlayStateDB) Suicide(addr common.Address) bool {
+	if !o.Exist(addr) {
+		return false
+	}
+	o.updateAccount(addr, func(account *overlayAccount) {
+		account.suicided = true
+		account.balance = new(big.Int)
+	})
+	o.addOp(func(base StateDB) { base.Suicide(addr) })
+	return true
+}
+
+// Finalise removes the accounts suicided in the overlay. As with the chain's state,
+// snapshots taken before Finalise can no longer be reverted to.
+func (o *OverlayStateDB) Finalise(deleteEmptyObjects bool) {
+	for _, account := range o.accounts {
+		if account.suicided {
+			*account = overlayAccount{
+				balance: new(big.Int),
+				storage: make(map[common.Hash]common.Hash),
+				reset:   true,
+			}
+		}
+	}
+	o.ops = append(o.ops, func(base StateDB) { base.Finalise(deleteEmptyObjects) })
+	o.journal = nil
+}
+
+func (o *OverlayStateDB) Snapshot() int {
+	return len(o.journal)
+}
+
+func (o *OverlayStateDB) RevertToSnapshot(revid int) {
+	if revid > len(o.journal) {
+		panic(fmt.Errorf("revision id %v cannot be reverted", revid))
+	}
+	for i := len(o.journal) - 1; i >= revid; i-- {
+		o.journal[i]()
+	}
+	o.journal = o.journal[:revid]
+}
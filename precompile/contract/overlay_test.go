@@ -0,0 +1,151 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	overlayAddr  = common.HexToAddress("0x0300000000000000000000000000000000000001")
+	overlayOther = common.HexToAddress("0x0300000000000000000000000000000000000002")
+	overlayKey   = common.HexToHash("0x01")
+	overlayTopic = common.HexToHash("0x02")
+)
+
+func newOverlayBase(t *testing.T) *state.StateDB {
+	base := state.NewTestStateDB(t).(*state.StateDB)
+	base.SetState(overlayAddr, overlayKey, common.HexToHash("0x0a"))
+	base.AddBalance(overlayAddr, big.NewInt(10))
+	base.SetNonce(overlayAddr, 1)
+	return base
+}
+
+func TestOverlayStateDBReadsThrough(t *testing.T) {
+	require := require.New(t)
+	base := newOverlayBase(t)
+	overlay := contract.NewOverlayStateDB(base)
+
+	require.Equal(common.HexToHash("0x0a"), overlay.GetState(overlayAddr, overlayKey))
+	require.Equal(big.NewInt(10), overlay.GetBalance(overlayAddr))
+	require.Equal(uint64(1), overlay.GetNonce(overlayAddr))
+	require.True(overlay.Exist(overlayAddr))
+	require.False(overlay.Exist(overlayOther))
+
+	overlay.SetState(overlayAddr, overlayKey, common.HexToHash("0x0b"))
+	overlay.AddBalance(overlayAddr, big.NewInt(5))
+	overlay.SetNonce(overlayOther, 2)
+	overlay.AddLog(overlayAddr, []common.Hash{overlayTopic}, []byte("data"), 0)
+	require.Equal(common.HexToHash("0x0b"), overlay.GetState(overlayAddr, overlayKey))
+	require.Equal(big.NewInt(15), overlay.GetBalance(overlayAddr))
+	require.True(overlay.Exist(overlayOther))
+	require.Equal([][]byte{[]byte("data")}, overlay.GetLogData())
+
+	// The base state is not written to.
+	require.Equal(common.HexToHash("0x0a"), base.GetState(overlayAddr, overlayKey))
+	require.Equal(big.NewInt(10), base.GetBalance(overlayAddr))
+	require.False(base.Exist(overlayOther))
+	require.Empty(base.Logs())
+
+	// A recreated account has empty storage but keeps its balance.
+	overlay.CreateAccount(overlayAddr)
+	require.Equal(common.Hash{}, overlay.GetState(overlayAddr, overlayKey))
+	require.Equal(big.NewInt(15), overlay.GetBalance(overlayAddr))
+	require.Zero(overlay.GetNonce(overlayAddr))
+}
+
+func TestOverlayStateDBSnapshotRevert(t *testing.T) {
+	require := require.New(t)
+	base := newOverlayBase(t)
+	overlay := contract.NewOverlayStateDB(base)
+
+	overlay.SetState(overlayAddr, overlayKey, common.HexToHash("0x0b"))
+	outer := overlay.Snapshot()
+	overlay.AddBalance(overlayAddr, big.NewInt(5))
+	overlay.AddLog(overlayAddr, []common.Hash{overlayTopic}, []byte("outer"), 0)
+	overlay.SetPredicateStorageSlots(overlayAddr, [][]byte{[]byte("predicate")})
+	inner := overlay.Snapshot()
+	overlay.SetState(overlayAddr, overlayKey, common.HexToHash("0x0c"))
+	require.True(overlay.Suicide(overlayAddr))
+	overlay.AddLog(overlayAddr, nil, []byte("inner"), 0)
+
+	overlay.RevertToSnapshot(inner)
+	require.Equal(common.HexToHash("0x0b"), overlay.GetState(overlayAddr, overlayKey))
+	require.Equal(big.NewInt(15), overlay.GetBalance(overlayAddr))
+	require.Equal([][]byte{[]byte("outer")}, overlay.GetLogData())
+	predicate, ok := overlay.GetPredicateStorageSlots(overlayAddr, 0)
+	require.True(ok)
+	require.Equal([]byte("predicate"), predicate)
+
+	overlay.RevertToSnapshot(outer)
+	require.Equal(common.HexToHash("0x0b"), overlay.GetState(overlayAddr, overlayKey))
+	require.Equal(big.NewInt(10), overlay.GetBalance(overlayAddr))
+	require.Empty(overlay.GetLogData())
+	_, ok = overlay.GetPredicateStorageSlots(overlayAddr, 0)
+	require.False(ok)
+
+	require.Panics(func() { overlay.RevertToSnapshot(inner) })
+}
+
+func TestOverlayStateDBCommit(t *testing.T) {
+	require := require.New(t)
+	base := newOverlayBase(t)
+	overlay := contract.NewOverlayStateDB(base)
+
+	overlay.SetState(overlayAddr, overlayKey, common.HexToHash("0x0b"))
+	overlay.AddBalance(overlayOther, big.NewInt(3))
+	topics := []common.Hash{overlayTopic, overlayOther.Hash()}
+	data := []byte("data")
+	overlay.AddLog(overlayAddr, topics, data, 7)
+	// The overlay keeps its own copy of the log.
+	topics[0] = common.Hash{}
+	data[0] = 'D'
+	snapshot := overlay.Snapshot()
+	overlay.SetNonce(overlayAddr, 5)
+	overlay.AddLog(overlayOther, nil, []byte("reverted"), 7)
+	overlay.RevertToSnapshot(snapshot)
+
+	overlay.Commit()
+	require.Equal(common.HexToHash("0x0b"), base.GetState(overlayAddr, overlayKey))
+	require.Equal(big.NewInt(3), base.GetBalance(overlayOther))
+	require.Equal(uint64(1), base.GetNonce(overlayAddr))
+	require.Equal([]*types.Log{{
+		Address:     overlayAddr,
+		Topics:      []common.Hash{overlayTopic, overlayOther.Hash()},
+		Data:        []byte("data"),
+		BlockNumber: 7,
+	}}, base.Logs())
+
+	// The overlay reads through to the updated base state, and a second commit applies only new writes.
+	require.Len(overlay.GetLogData(), 1)
+	overlay.AddBalance(overlayOther, big.NewInt(1))
+	overlay.Commit()
+	require.Equal(big.NewInt(4), base.GetBalance(overlayOther))
+	require.Len(base.Logs(), 1)
+}
+
+func TestOverlayStateDBCommitSuicide(t *testing.T) {
+	require := require.New(t)
+	base := newOverlayBase(t)
+	overlay := contract.NewOverlayStateDB(base)
+
+	require.False(overlay.Suicide(overlayOther))
+	require.True(overlay.Suicide(overlayAddr))
+	require.Zero(overlay.GetBalance(overlayAddr).Sign())
+	overlay.Finalise(true)
+	require.False(overlay.Exist(overlayAddr))
+	require.Equal(common.Hash{}, overlay.GetState(overlayAddr, overlayKey))
+	require.True(base.Exist(overlayAddr))
+
+	overlay.Commit()
+	require.False(base.Exist(overlayAddr))
+	require.Equal(common.Hash{}, base.GetState(overlayAddr, overlayKey))
+}
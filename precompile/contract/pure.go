@@ -0,0 +1,57 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var _ StatefulPrecompiledContract = (*pureCachedContract)(nil)
+
+// pureResult is the result of a successful call to a pure precompile.
+type pureResult struct {
+	ret     []byte
+	gasUsed uint64
+}
+
+// pureCachedContract caches the results of a pure precompile by the hash of their input.
+type pureCachedContract struct {
+	contract StatefulPrecompiledContract
+	results  *cache.LRU[common.Hash, pureResult]
+}
+
+// NewPureCachedContract returns [contract] with the results of its last [size] distinct successful calls
+// cached by input, so that repeated calls with the same input, such as within a block, skip recomputation.
+// A cached result still charges the gas used by the original call, and fails with vmerrs.ErrOutOfGas if
+// less gas is supplied. Failed calls are not cached.
+// [contract] must be pure: its result and gas cost must depend only on its input, and it must not read or
+// write state or depend on the caller, the block or the chain config.
+func NewPureCachedContract(contract StatefulPrecompiledContract, size int) StatefulPrecompiledContract {
+	return &pureCachedContract{
+		contract: contract,
+		results:  &cache.LRU[common.Hash, pureResult]{Size: size},
+	}
+}
+
+func (c *pureCachedContract) Run(accessibleState AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+	key := crypto.Keccak256Hash(input)
+	if result, ok := c.results.Get(key); ok {
+		remainingGas, err := DeductGas(suppliedGas, result.gasUsed)
+		if err != nil {
+			return nil, 0, err
+		}
+		return common.CopyBytes(result.ret), remainingGas, nil
+	}
+
+	ret, remainingGas, err := c.contract.Run(accessibleState, caller, addr, input, suppliedGas, readOnly)
+	if err == nil {
+		c.results.Put(key, pureResult{
+			ret:     common.CopyBytes(ret),
+			gasUsed: suppliedGas - remainingGas,
+		})
+	}
+	return ret, remainingGas, err
+}
@@ -0,0 +1,80 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StorageKey returns the storage key of [addr] in the namespace [prefix]. The first byte of the key is
+// [prefix] and the last 20 bytes are [addr], so keys with different prefixes never collide.
+// The key with prefix 0 is addr.Hash(), which is the key the allow list stores the role of [addr] under,
+// so precompiles with an allow list must use a non-zero prefix for their own keys.
+func StorageKey(prefix byte, addr common.Address) common.Hash {
+	key := addr.Hash()
+	key[0] = prefix
+	return key
+}
+
+// Uint64ToWord returns [v] as a 32 byte big endian word, as it is stored in a storage slot.
+func Uint64ToWord(v uint64) common.Hash {
+	var word common.Hash
+	binary.BigEndian.PutUint64(word[common.HashLength-8:], v)
+	return word
+}
+
+// WordToUint64 returns the uint64 stored in [word] by Uint64ToWord.
+// Returns false if [word] does not fit in a uint64.
+func WordToUint64(word common.Hash) (uint64, bool) {
+	if !isZero(word[:common.HashLength-8]) {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(word[common.HashLength-8:]), true
+}
+
+// AddressToWord returns [addr] left padded to a 32 byte word, as it is stored in a storage slot.
+func AddressToWord(addr common.Address) common.Hash {
+	return addr.Hash()
+}
+
+// WordToAddress returns the address stored in [word] by AddressToWord.
+// Returns false if the upper 12 bytes of [word] are not zero, as it then does not hold an address.
+func WordToAddress(word common.Hash) (common.Address, bool) {
+	if !isZero(word[:common.HashLength-common.AddressLength]) {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(word[:]), true
+}
+
+// BoolToWord returns [b] as a 32 byte word holding 1 or 0, as it is stored in a storage slot.
+func BoolToWord(b bool) common.Hash {
+	if b {
+		return common.Hash{31: 1}
+	}
+	return common.Hash{}
+}
+
+// WordToBool returns the bool stored in [word] by BoolToWord.
+// Returns false as the second value if [word] is neither 0 nor 1.
+func WordToBool(word common.Hash) (bool, bool) {
+	switch word {
+	case common.Hash{}:
+		return false, true
+	case common.Hash{31: 1}:
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageKey(t *testing.T) {
+	addr := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+
+	// The key with prefix 0 is the allow list key of the address.
+	require.Equal(t, addr.Hash(), StorageKey(0, addr))
+
+	key := StorageKey('r', addr)
+	require.Equal(t, byte('r'), key[0])
+	require.Equal(t, addr, common.BytesToAddress(key[:]))
+	require.NotEqual(t, key, StorageKey('s', addr))
+	require.NotEqual(t, key, StorageKey('r', common.Address{}))
+}
+
+func TestUint64Word(t *testing.T) {
+	for _, v := range []uint64{0, 1, 1 << 32, math.MaxUint64} {
+		word := Uint64ToWord(v)
+		require.Equal(t, common.BigToHash(new(big.Int).SetUint64(v)), word)
+		unpacked, ok := WordToUint64(word)
+		require.True(t, ok)
+		require.Equal(t, v, unpacked)
+	}
+
+	_, ok := WordToUint64(common.Hash{23: 1})
+	require.False(t, ok)
+}
+
+func TestAddressWord(t *testing.T) {
+	addr := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	word := AddressToWord(addr)
+	require.Equal(t, addr.Hash(), word)
+	unpacked, ok := WordToAddress(word)
+	require.True(t, ok)
+	require.Equal(t, addr, unpacked)
+
+	_, ok = WordToAddress(StorageKey('r', addr))
+	require.False(t, ok)
+}
+
+func TestBoolWord(t *testing.T) {
+	for _, b := range []bool{false, true} {
+		unpacked, ok := WordToBool(BoolToWord(b))
+		require.True(t, ok)
+		require.Equal(t, b, unpacked)
+	}
+	require.Equal(t, common.Big1, BoolToWord(true).Big())
+
+	_, ok := WordToBool(common.Hash{31: 2})
+	require.False(t, ok)
+}
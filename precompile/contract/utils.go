@@ -78,6 +78,35 @@ func PackedHash(packed []byte, index int) []byte {
 	return packed[start:end]
 }
 
+// CheckPackedLength returns an error wrapping ErrInvalidInputLength unless [packed] is
+// exactly [numSlots] 32 byte segments long.
+func CheckPackedLength(packed []byte, numSlots int) error {
+	if expected := numSlots * common.HashLength; len(packed) != expected {
+		return fmt.Errorf("%w: expected %d bytes but got %d", ErrInvalidInputLength, expected, len(packed))
+	}
+	return nil
+}
+
+// UnpackHash returns the 32 byte segment of [input] starting at [offset], or an error wrapping
+// ErrInvalidInputLength if [input] is too short.
+func UnpackHash(input []byte, offset int) (common.Hash, error) {
+	if offset < 0 || len(input) < offset+common.HashLength {
+		return common.Hash{}, fmt.Errorf("%w: cannot read %d bytes at offset %d from %d bytes", ErrInvalidInputLength, common.HashLength, offset, len(input))
+	}
+	return common.BytesToHash(input[offset : offset+common.HashLength]), nil
+}
+
+// UnpackAddress returns the address packed in the 32 byte segment of [input] starting at [offset],
+// or an error wrapping ErrInvalidInputLength if [input] is too short.
+// As with common.BytesToAddress, the upper 12 bytes of the segment are ignored.
+func UnpackAddress(input []byte, offset int) (common.Address, error) {
+	hash, err := UnpackHash(input, offset)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(hash[:]), nil
+}
+
 // ParseABI parses the given ABI string and returns the parsed ABI.
 // If the ABI is invalid, it panics.
 func ParseABI(rawABI string) abi.ABI {
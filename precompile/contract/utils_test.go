@@ -6,7 +6,9 @@ package contract
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFunctionSignatureRegex(t *testing.T) {
@@ -64,3 +66,23 @@ func TestFunctionSignatureRegex(t *testing.T) {
 		assert.Equal(t, test.pass, functionSignatureRegex.MatchString(test.str), "unexpected result for %q", test.str)
 	}
 }
+
+func TestUnpackAddress(t *testing.T) {
+	addr := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	input := append(common.Hash{1}.Bytes(), addr.Hash().Bytes()...)
+
+	unpacked, err := UnpackAddress(input, common.HashLength)
+	require.NoError(t, err)
+	require.Equal(t, addr, unpacked)
+
+	_, err = UnpackAddress(input, common.HashLength+1)
+	require.ErrorIs(t, err, ErrInvalidInputLength)
+	_, err = UnpackAddress(input, -1)
+	require.ErrorIs(t, err, ErrInvalidInputLength)
+	_, err = UnpackAddress(nil, 0)
+	require.ErrorIs(t, err, ErrInvalidInputLength)
+
+	require.NoError(t, CheckPackedLength(input, 2))
+	require.ErrorIs(t, CheckPackedLength(input, 1), ErrInvalidInputLength)
+	require.ErrorIs(t, CheckPackedLength(input[1:], 2), ErrInvalidInputLength)
+}
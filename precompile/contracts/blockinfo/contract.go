@@ -0,0 +1,108 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package blockinfo is a sample precompile that exposes fields of the current block context.
+// It is not imported by the precompile registry, so it cannot be enabled on a chain.
+package blockinfo
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	GetBlockNumberGasCost    uint64 = 2 // Based on GasQuickStep used by the NUMBER instruction
+	GetBlockTimestampGasCost uint64 = 2 // Based on GasQuickStep used by the TIMESTAMP instruction
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	// BlockInfoRawABI contains the raw ABI of BlockInfo contract.
+	//go:embed contract.abi
+	BlockInfoRawABI string
+
+	BlockInfoABI        = contract.ParseABI(BlockInfoRawABI)
+	BlockInfoPrecompile = createBlockInfoPrecompile()
+)
+
+// PackGetBlockNumber packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetBlockNumber() ([]byte, error) {
+	return BlockInfoABI.Pack("getBlockNumber")
+}
+
+// PackGetBlockNumberOutput attempts to pack given blockNumber of type *big.Int
+// to conform the ABI outputs.
+func PackGetBlockNumberOutput(blockNumber *big.Int) ([]byte, error) {
+	return BlockInfoABI.PackOutput("getBlockNumber", blockNumber)
+}
+
+// getBlockNumber returns the number of the block being processed.
+func getBlockNumber(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetBlockNumberGasCost); err != nil {
+		return nil, 0, err
+	}
+	packedOutput, err := PackGetBlockNumberOutput(accessibleState.GetBlockContext().Number())
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	// Return the packed output and the remaining gas
+	return packedOutput, remainingGas, nil
+}
+
+// PackGetBlockTimestamp packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetBlockTimestamp() ([]byte, error) {
+	return BlockInfoABI.Pack("getBlockTimestamp")
+}
+
+// PackGetBlockTimestampOutput attempts to pack given timestamp of type uint64
+// to conform the ABI outputs.
+func PackGetBlockTimestampOutput(timestamp uint64) ([]byte, error) {
+	return BlockInfoABI.PackOutput("getBlockTimestamp", new(big.Int).SetUint64(timestamp))
+}
+
+// getBlockTimestamp returns the timestamp of the block being processed.
+func getBlockTimestamp(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetBlockTimestampGasCost); err != nil {
+		return nil, 0, err
+	}
+	packedOutput, err := PackGetBlockTimestampOutput(accessibleState.GetBlockContext().Timestamp())
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	// Return the packed output and the remaining gas
+	return packedOutput, remainingGas, nil
+}
+
+// createBlockInfoPrecompile returns a StatefulPrecompiledContract with getters for the block context.
+func createBlockInfoPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"getBlockNumber":    getBlockNumber,
+		"getBlockTimestamp": getBlockTimestamp,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := BlockInfoABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,113 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package blockinfo
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlockNumber(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+
+	tests := map[string]testutils.PrecompileTest{
+		"getBlockNumber insufficient gas": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetBlockNumber()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: GetBlockNumberGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+	}
+	for _, blockNumber := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(1_000_000), new(big.Int).SetUint64(^uint64(0))} {
+		blockNumber := blockNumber
+		for _, readOnly := range []bool{false, true} {
+			tests[fmt.Sprintf("getBlockNumber at block %s readOnly %t", blockNumber, readOnly)] = testutils.PrecompileTest{
+				Caller: callerAddr,
+				InputFn: func(t testing.TB) []byte {
+					input, err := PackGetBlockNumber()
+					require.NoError(t, err)
+
+					return input
+				},
+				SetupBlockContext: func(mbc *contract.MockBlockContext) {
+					mbc.EXPECT().Number().Return(blockNumber).AnyTimes()
+				},
+				SuppliedGas: GetBlockNumberGasCost,
+				ReadOnly:    readOnly,
+				ExpectedRes: func() []byte {
+					expectedOutput, err := PackGetBlockNumberOutput(blockNumber)
+					require.NoError(t, err)
+
+					return expectedOutput
+				}(),
+				ExpectedResABI:    BlockInfoABI.Methods["getBlockNumber"].Outputs,
+				ExpectedResValues: []any{blockNumber},
+			}
+		}
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests, false)
+}
+
+func TestGetBlockTimestamp(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+
+	tests := map[string]testutils.PrecompileTest{
+		"getBlockTimestamp insufficient gas": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetBlockTimestamp()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: GetBlockTimestampGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+	}
+	for _, blockNumber := range []uint64{0, 1, 1_000_000} {
+		blockNumber := blockNumber
+		timestamp := 1_700_000_000 + 2*blockNumber
+		tests[fmt.Sprintf("getBlockTimestamp at block %d", blockNumber)] = testutils.PrecompileTest{
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetBlockTimestamp()
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Number().Return(new(big.Int).SetUint64(blockNumber)).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(timestamp).AnyTimes()
+			},
+			SuppliedGas: GetBlockTimestampGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				expectedOutput, err := PackGetBlockTimestampOutput(timestamp)
+				require.NoError(t, err)
+
+				return expectedOutput
+			}(),
+			ExpectedResABI:    BlockInfoABI.Methods["getBlockTimestamp"].Outputs,
+			ExpectedResValues: []any{new(big.Int).SetUint64(timestamp)},
+		}
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests, false)
+}
@@ -67,6 +67,18 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 	return c.InitialFeeConfig.Equal(other.InitialFeeConfig)
 }
 
+// Copy returns a deep copy of [c].
+func (c *Config) Copy() precompileconfig.Config {
+	config := &Config{
+		AllowListConfig: c.AllowListConfig.Copy(),
+		Upgrade:         c.Upgrade.Copy(),
+	}
+	if c.InitialFeeConfig != nil {
+		config.InitialFeeConfig = c.InitialFeeConfig.Copy()
+	}
+	return config
+}
+
 func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 	if err := c.AllowListConfig.Verify(chainConfig, c.Upgrade); err != nil {
 		return err
@@ -88,3 +88,11 @@ func TestEqual(t *testing.T) {
 	}
 	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
 }
+
+func TestConfigRoundTrip(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	enableds := []common.Address{allowlist.TestEnabledAddr}
+	managers := []common.Address{allowlist.TestManagerAddr}
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewConfig(utils.NewUint64(3), admins, enableds, managers, &validFeeConfig))
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewDisableConfig(utils.NewUint64(4)))
+}
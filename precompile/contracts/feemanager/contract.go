@@ -4,7 +4,6 @@
 package feemanager
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
 
@@ -49,7 +48,7 @@ var (
 
 	feeConfigLastChangedAtKey = common.Hash{'l', 'c', 'a'}
 
-	ErrCannotChangeFee = errors.New("non-enabled cannot change fee config")
+	ErrCannotChangeFee = contract.NewCallerNotAllowedError("non-enabled cannot change fee config")
 )
 
 // GetFeeManagerStatus returns the role of [address] for the fee config manager list.
@@ -112,7 +111,7 @@ func packFeeConfigHelper(feeConfig commontype.FeeConfig, useSelector bool) ([]by
 // assumes that [input] does not include selector (omits first 4 bytes in PackSetFeeConfigInput)
 func UnpackFeeConfigInput(input []byte) (commontype.FeeConfig, error) {
 	if len(input) != feeConfigInputLen {
-		return commontype.FeeConfig{}, fmt.Errorf("invalid input length for fee config Input: %d", len(input))
+		return commontype.FeeConfig{}, fmt.Errorf("%w for fee config Input: %d", contract.ErrInvalidInputLength, len(input))
 	}
 	feeConfig := commontype.FeeConfig{}
 	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
@@ -255,6 +255,17 @@ func TestFeeManager(t *testing.T) {
 	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
 }
 
+func TestFeeManagerConcurrent(t *testing.T) {
+	for name, test := range tests {
+		if !test.ReadOnly {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			testutils.RunConcurrent(t, Module, state.NewTestStateDB, test, 8)
+		})
+	}
+}
+
 func BenchmarkFeeManager(b *testing.B) {
 	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
 }
@@ -82,6 +82,25 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 	return true
 }
 
+// Copy returns a deep copy of [c].
+func (c *Config) Copy() precompileconfig.Config {
+	config := &Config{
+		AllowListConfig: c.AllowListConfig.Copy(),
+		Upgrade:         c.Upgrade.Copy(),
+	}
+	if c.InitialMint != nil {
+		config.InitialMint = make(map[common.Address]*math.HexOrDecimal256, len(c.InitialMint))
+		for address, amount := range c.InitialMint {
+			if amount == nil {
+				config.InitialMint[address] = nil
+				continue
+			}
+			config.InitialMint[address] = (*math.HexOrDecimal256)(new(big.Int).Set((*big.Int)(amount)))
+		}
+	}
+	return config
+}
+
 func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 	// ensure that all of the initial mint values in the map are non-nil positive values
 	for addr, amount := range c.InitialMint {
@@ -4,6 +4,8 @@
 package nativeminter
 
 import (
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
@@ -12,6 +14,7 @@ import (
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -108,3 +111,61 @@ func TestEqual(t *testing.T) {
 	}
 	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
 }
+
+func TestCopy(t *testing.T) {
+	require := require.New(t)
+	admins := []common.Address{allowlist.TestAdminAddr}
+	config := NewConfig(utils.NewUint64(3), admins, nil, nil, map[common.Address]*math.HexOrDecimal256{
+		common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+	})
+
+	copied := config.Copy().(*Config)
+	require.True(config.Equal(copied))
+
+	// Mutating the copy must not affect the original.
+	*copied.BlockTimestamp = 4
+	copied.AdminAddresses[0] = allowlist.TestEnabledAddr
+	(*big.Int)(copied.InitialMint[common.HexToAddress("0x01")]).SetInt64(2)
+	require.Equal(uint64(3), *config.BlockTimestamp)
+	require.Equal([]common.Address{allowlist.TestAdminAddr}, config.AdminAddresses)
+	require.Equal(math.NewHexOrDecimal256(1), config.InitialMint[common.HexToAddress("0x01")])
+}
+
+func TestDiff(t *testing.T) {
+	require := require.New(t)
+	admins := []common.Address{allowlist.TestAdminAddr}
+	config := NewConfig(utils.NewUint64(3), admins, nil, nil, nil)
+
+	diffs, err := precompileconfig.Diff(config, NewConfig(utils.NewUint64(3), admins, nil, nil, nil))
+	require.NoError(err)
+	require.Empty(diffs)
+
+	diffs, err = precompileconfig.Diff(config, NewConfig(utils.NewUint64(4), []common.Address{allowlist.TestManagerAddr}, nil, nil, map[common.Address]*math.HexOrDecimal256{
+		common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+	}))
+	require.NoError(err)
+	require.Equal([]string{
+		`adminAddresses: added "` + strings.ToLower(allowlist.TestManagerAddr.Hex()) + `"`,
+		`adminAddresses: removed "` + strings.ToLower(allowlist.TestAdminAddr.Hex()) + `"`,
+		"blockTimestamp: 3 -> 4",
+		`initialMint: <unset> -> {"0x0000000000000000000000000000000000000001":"0x1"}`,
+	}, diffs)
+
+	diffs, err = precompileconfig.Diff(config, NewDisableConfig(utils.NewUint64(3)))
+	require.NoError(err)
+	require.Equal([]string{
+		`adminAddresses: removed "` + strings.ToLower(allowlist.TestAdminAddr.Hex()) + `"`,
+		"disable: <unset> -> true",
+	}, diffs)
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	enableds := []common.Address{allowlist.TestEnabledAddr}
+	managers := []common.Address{allowlist.TestManagerAddr}
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewConfig(utils.NewUint64(3), admins, enableds, managers,
+		map[common.Address]*math.HexOrDecimal256{
+			common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+		}))
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewDisableConfig(utils.NewUint64(4)))
+}
@@ -4,7 +4,6 @@
 package nativeminter
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
 
@@ -21,6 +20,9 @@ const (
 	mintInputLen = common.HashLength + common.HashLength
 
 	MintGasCost = 30_000
+
+	// mintFunctionName is the name of the mint function in the gas cost overrides of the chain config.
+	mintFunctionName = "mintNativeCoin"
 )
 
 var (
@@ -28,7 +30,7 @@ var (
 	ContractNativeMinterPrecompile contract.StatefulPrecompiledContract = createNativeMinterPrecompile()
 
 	mintSignature = contract.CalculateFunctionSelector("mintNativeCoin(address,uint256)") // address, amount
-	ErrCannotMint = errors.New("non-enabled cannot mint")
+	ErrCannotMint = contract.NewCallerNotAllowedError("non-enabled cannot mint")
 )
 
 // GetContractNativeMinterStatus returns the role of [address] for the minter list.
@@ -59,17 +61,24 @@ func PackMintInput(address common.Address, amount *big.Int) ([]byte, error) {
 // assumes that [input] does not include selector (omits first 4 bytes in PackMintInput)
 func UnpackMintInput(input []byte) (common.Address, *big.Int, error) {
 	if len(input) != mintInputLen {
-		return common.Address{}, nil, fmt.Errorf("invalid input length for minting: %d", len(input))
+		return common.Address{}, nil, fmt.Errorf("%w for minting: %d", contract.ErrInvalidInputLength, len(input))
+	}
+	to, err := contract.UnpackAddress(input, mintInputAddressSlot*common.HashLength)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	amount, err := contract.UnpackHash(input, mintInputAmountSlot*common.HashLength)
+	if err != nil {
+		return common.Address{}, nil, err
 	}
-	to := common.BytesToAddress(contract.PackedHash(input, mintInputAddressSlot))
-	assetAmount := new(big.Int).SetBytes(contract.PackedHash(input, mintInputAmountSlot))
-	return to, assetAmount, nil
+	return to, amount.Big(), nil
 }
 
 // mintNativeCoin checks if the caller is permissioned for minting operation.
 // The execution function parses the [input] into native coin amount and receiver address.
 func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
-	if remainingGas, err = contract.DeductGas(suppliedGas, MintGasCost); err != nil {
+	mintGasCost := accessibleState.GetChainConfig().GetPrecompileGasCost(ConfigKey, mintFunctionName, accessibleState.GetBlockContext().Timestamp(), MintGasCost)
+	if remainingGas, err = contract.DeductGas(suppliedGas, mintGasCost); err != nil {
 		return nil, 0, err
 	}
 
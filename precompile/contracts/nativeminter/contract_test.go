@@ -7,9 +7,11 @@ import (
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -167,3 +169,132 @@ func TestContractNativeMinterRun(t *testing.T) {
 func BenchmarkContractNativeMinter(b *testing.B) {
 	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
 }
+
+func TestContractNativeMinterUpgrade(t *testing.T) {
+	mintInput := func(t testing.TB) []byte {
+		input, err := PackMintInput(allowlist.TestAdminAddr, common.Big1)
+		require.NoError(t, err)
+
+		return input
+	}
+	testutils.RunUpgradeTest(t, Module, state.NewTestStateDB, []testutils.UpgradePhase{
+		{
+			Config: NewConfig(utils.NewUint64(1), []common.Address{allowlist.TestAdminAddr}, nil, nil, nil),
+			Test: testutils.PrecompileTest{
+				Caller:      allowlist.TestAdminAddr,
+				InputFn:     mintInput,
+				SuppliedGas: MintGasCost,
+				ExpectedRes: []byte{},
+			},
+		},
+		{
+			// Disabling the precompile clears its allow list.
+			Config: NewDisableConfig(utils.NewUint64(2)),
+			Test: testutils.PrecompileTest{
+				Caller:        allowlist.TestAdminAddr,
+				InputFn:       mintInput,
+				SuppliedGas:   MintGasCost,
+				ExpectedErrIs: contract.ErrCallerNotAllowed,
+			},
+		},
+		{
+			// Re-enabling the precompile does not restore the previous admins.
+			Config: NewConfig(utils.NewUint64(3), []common.Address{allowlist.TestEnabledAddr}, nil, nil, nil),
+			Test: testutils.PrecompileTest{
+				Caller:        allowlist.TestAdminAddr,
+				InputFn:       mintInput,
+				SuppliedGas:   MintGasCost,
+				ExpectedErrIs: contract.ErrCallerNotAllowed,
+				AfterHook: func(t testing.TB, state contract.StateDB) {
+					require.Equal(t, allowlist.AdminRole, allowlist.GetAllowListStatus(state, Module.Address, allowlist.TestEnabledAddr))
+				},
+			},
+		},
+	})
+}
+
+func TestContractNativeMinterOverlay(t *testing.T) {
+	require := require.New(t)
+	base := state.NewTestStateDB(t)
+	allowlist.SetDefaultRoles(Module.Address)(t, base)
+	overlay := contract.NewOverlayStateDB(base)
+
+	snapshot := overlay.Snapshot()
+	test := testutils.PrecompileTest{
+		Caller: allowlist.TestEnabledAddr,
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(common.Big1, state.GetBalance(allowlist.TestEnabledAddr), "expected minted funds in the overlay")
+		},
+	}
+	test.Run(t, Module, overlay)
+
+	// The mint is only visible through the overlay.
+	require.Zero(base.GetBalance(allowlist.TestEnabledAddr).Sign(), "expected base state to be untouched")
+	require.Equal(allowlist.EnabledRole, allowlist.GetAllowListStatus(overlay, Module.Address, allowlist.TestEnabledAddr))
+
+	overlay.RevertToSnapshot(snapshot)
+	require.Zero(overlay.GetBalance(allowlist.TestEnabledAddr).Sign(), "expected mint to be reverted")
+}
+
+func TestContractNativeMinterGasCostOverride(t *testing.T) {
+	const (
+		overrideTimestamp = 10
+		overriddenGasCost = 2 * MintGasCost
+	)
+	chainConfig := *params.TestChainConfig
+	chainConfig.PrecompileGasCosts = []params.PrecompileGasCostUpgrade{
+		{
+			BlockTimestamp: utils.NewUint64(overrideTimestamp),
+			GasCosts:       map[string]map[string]uint64{ConfigKey: {mintFunctionName: overriddenGasCost}},
+		},
+	}
+	require.NoError(t, chainConfig.Verify())
+	atTimestamp := func(timestamp uint64) func(*contract.MockBlockContext) {
+		return func(c *contract.MockBlockContext) {
+			c.EXPECT().Timestamp().Return(timestamp).AnyTimes()
+		}
+	}
+	mintInput := func(t testing.TB) []byte {
+		input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+		require.NoError(t, err)
+
+		return input
+	}
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, map[string]testutils.PrecompileTest{
+		"mint before the override activates uses the default gas cost": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			ChainConfig:       &chainConfig,
+			SetupBlockContext: atTimestamp(overrideTimestamp - 1),
+			InputFn:           mintInput,
+			SuppliedGas:       MintGasCost,
+			ExpectedRes:       []byte{},
+		},
+		"mint with default gas cost runs out of gas": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			ChainConfig:       &chainConfig,
+			SetupBlockContext: atTimestamp(overrideTimestamp),
+			InputFn:           mintInput,
+			SuppliedGas:       MintGasCost,
+			ExpectedErrIs:     vmerrs.ErrOutOfGas,
+		},
+		"mint with overridden gas cost": {
+			Caller:            allowlist.TestEnabledAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			ChainConfig:       &chainConfig,
+			SetupBlockContext: atTimestamp(overrideTimestamp),
+			InputFn:           mintInput,
+			SuppliedGas:       overriddenGasCost,
+			ExpectedRes:       []byte{},
+		},
+	}, false)
+}
@@ -25,6 +25,7 @@ var Module = modules.Module{
 	ConfigKey:    ConfigKey,
 	Address:      ContractAddress,
 	Contract:     ContractNativeMinterPrecompile,
+	GasCosts:     map[string]uint64{mintFunctionName: MintGasCost},
 	Configurator: &configurator{},
 }
 
@@ -115,3 +115,16 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 
 	return c.Upgrade.Equal(&other.Upgrade) && c.AllowListConfig.Equal(&other.AllowListConfig)
 }
+
+// Copy returns a deep copy of [c].
+func (c *Config) Copy() precompileconfig.Config {
+	config := &Config{
+		AllowListConfig: c.AllowListConfig.Copy(),
+		Upgrade:         c.Upgrade.Copy(),
+	}
+	if c.InitialRewardConfig != nil {
+		initialRewardConfig := *c.InitialRewardConfig
+		config.InitialRewardConfig = &initialRewardConfig
+	}
+	return config
+}
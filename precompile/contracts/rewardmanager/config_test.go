@@ -79,3 +79,13 @@ func TestEqual(t *testing.T) {
 	}
 	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
 }
+
+func TestConfigRoundTrip(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	enableds := []common.Address{allowlist.TestEnabledAddr}
+	managers := []common.Address{allowlist.TestManagerAddr}
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+		RewardAddress: common.HexToAddress("0x01"),
+	}))
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewDisableConfig(utils.NewUint64(4)))
+}
@@ -30,11 +30,11 @@ const (
 
 // Singleton StatefulPrecompiledContract and signatures.
 var (
-	ErrCannotAllowFeeRecipients      = errors.New("non-enabled cannot call allowFeeRecipients")
-	ErrCannotAreFeeRecipientsAllowed = errors.New("non-enabled cannot call areFeeRecipientsAllowed")
-	ErrCannotCurrentRewardAddress    = errors.New("non-enabled cannot call currentRewardAddress")
-	ErrCannotDisableRewards          = errors.New("non-enabled cannot call disableRewards")
-	ErrCannotSetRewardAddress        = errors.New("non-enabled cannot call setRewardAddress")
+	ErrCannotAllowFeeRecipients      = contract.NewCallerNotAllowedError("non-enabled cannot call allowFeeRecipients")
+	ErrCannotAreFeeRecipientsAllowed = contract.NewCallerNotAllowedError("non-enabled cannot call areFeeRecipientsAllowed")
+	ErrCannotCurrentRewardAddress    = contract.NewCallerNotAllowedError("non-enabled cannot call currentRewardAddress")
+	ErrCannotDisableRewards          = contract.NewCallerNotAllowedError("non-enabled cannot call disableRewards")
+	ErrCannotSetRewardAddress        = contract.NewCallerNotAllowedError("non-enabled cannot call setRewardAddress")
 
 	ErrCannotEnableBothRewards = errors.New("cannot enable both fee recipients and reward address at the same time")
 	ErrEmptyRewardAddress      = errors.New("reward address cannot be empty")
@@ -74,7 +74,7 @@ func EnableAllowFeeRecipients(stateDB contract.StateDB) {
 
 // DisableRewardAddress disables rewards and burns them by sending to Blackhole Address.
 func DisableFeeRewards(stateDB contract.StateDB) {
-	stateDB.SetState(ContractAddress, rewardAddressStorageKey, constants.BlackholeAddr.Hash())
+	stateDB.SetState(ContractAddress, rewardAddressStorageKey, contract.AddressToWord(constants.BlackholeAddr))
 }
 
 func allowFeeRecipients(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
@@ -161,7 +161,7 @@ func StoreRewardAddress(stateDB contract.StateDB, val common.Address) error {
 	if val == (common.Address{}) {
 		return ErrEmptyRewardAddress
 	}
-	stateDB.SetState(ContractAddress, rewardAddressStorageKey, val.Hash())
+	stateDB.SetState(ContractAddress, rewardAddressStorageKey, contract.AddressToWord(val))
 	return nil
 }
 
@@ -253,6 +253,76 @@ var (
 				return res
 			}(),
 		},
+		"get are fee recipients allowed with excess gas": {
+			Caller:     allowlist.TestNoRoleAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackAreFeeRecipientsAllowed()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas:     AreFeeRecipientsAllowedGasCost + 1000,
+			ExpectedGasUsed: AreFeeRecipientsAllowedGasCost,
+			ReadOnly:        true,
+			ExpectedRes: func() []byte {
+				res, err := PackAreFeeRecipientsAllowedOutput(false)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"set reward address then disable rewards from enabled succeeds": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			Steps: []testutils.PrecompileStep{
+				{
+					Input: func() []byte {
+						input, err := PackSetRewardAddress(testAddr)
+						if err != nil {
+							panic(err)
+						}
+						return input
+					}(),
+					SuppliedGas: SetRewardAddressGasCost,
+					ExpectedRes: []byte{},
+				},
+				{
+					Input: func() []byte {
+						input, err := PackCurrentRewardAddress()
+						if err != nil {
+							panic(err)
+						}
+						return input
+					}(),
+					SuppliedGas: CurrentRewardAddressGasCost,
+					ReadOnly:    true,
+					ExpectedRes: func() []byte {
+						res, err := PackCurrentRewardAddressOutput(testAddr)
+						if err != nil {
+							panic(err)
+						}
+						return res
+					}(),
+				},
+				{
+					Input: func() []byte {
+						input, err := PackDisableRewards()
+						if err != nil {
+							panic(err)
+						}
+						return input
+					}(),
+					SuppliedGas: DisableRewardsGasCost,
+					ExpectedRes: []byte{},
+				},
+			},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				address, isFeeRecipients := GetStoredRewardAddress(state)
+				require.Equal(t, constants.BlackholeAddr, address)
+				require.False(t, isFeeRecipients)
+			},
+		},
 		"readOnly allow fee recipients with allowed role fails": {
 			Caller:     allowlist.TestEnabledAddr,
 			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
@@ -338,6 +408,55 @@ func TestRewardManagerRun(t *testing.T) {
 	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
 }
 
+func TestRewardManagerInputFnErr(t *testing.T) {
+	tests := map[string]testutils.PrecompileTest{
+		"set reward address from admin": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFnErr: func(t testing.TB) ([]byte, error) {
+				return PackSetRewardAddress(testAddr)
+			},
+			SuppliedGas: SetRewardAddressGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				address, isFeeRecipients := GetStoredRewardAddress(state)
+				require.Equal(t, testAddr, address)
+				require.False(t, isFeeRecipients)
+			},
+		},
+		"set reward address with missing argument fails to pack": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFnErr: func(t testing.TB) ([]byte, error) {
+				return RewardManagerABI.Pack("setRewardAddress")
+			},
+			SuppliedGas: SetRewardAddressGasCost,
+			ReadOnly:    false,
+			ExpectedErr: "argument count mismatch",
+		},
+	}
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests, false)
+}
+
 func BenchmarkRewardManager(b *testing.B) {
 	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
 }
+
+func FuzzRewardManager(f *testing.F) {
+	allowFeeRecipientsInput, err := PackAllowFeeRecipients()
+	require.NoError(f, err)
+	setRewardAddressInput, err := PackSetRewardAddress(testAddr)
+	require.NoError(f, err)
+	currentRewardAddressInput, err := PackCurrentRewardAddress()
+	require.NoError(f, err)
+
+	seeds := [][]byte{
+		allowFeeRecipientsInput,
+		setRewardAddressInput,
+		setRewardAddressInput[:len(setRewardAddressInput)-1],
+		currentRewardAddressInput,
+		allowlist.PackReadAllowList(allowlist.TestAdminAddr),
+	}
+	testutils.FuzzPrecompile(f, Module, state.NewTestStateDB, seeds)
+}
@@ -54,6 +54,14 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 	return c.Upgrade.Equal(&other.Upgrade) && c.AllowListConfig.Equal(&other.AllowListConfig)
 }
 
+// Copy returns a deep copy of [c].
+func (c *Config) Copy() precompileconfig.Config {
+	return &Config{
+		AllowListConfig: c.AllowListConfig.Copy(),
+		Upgrade:         c.Upgrade.Copy(),
+	}
+}
+
 func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
 }
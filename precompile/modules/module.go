@@ -18,6 +18,13 @@ type Module struct {
 	// Contract returns a thread-safe singleton that can be used as the StatefulPrecompiledContract when
 	// this config is enabled.
 	Contract contract.StatefulPrecompiledContract
+	// GasCosts are the default gas costs of the functions of Contract that the chain config can override,
+	// keyed by function name. Contract must read the cost of these functions with GetPrecompileGasCost.
+	GasCosts map[string]uint64
+	// Pure is true if the result and gas cost of Contract depend only on its input, in which case
+	// RegisterModule caches its results by input with contract.NewPureCachedContract.
+	// A pure module cannot have GasCosts, as their cost depends on the chain config.
+	Pure bool
 	// Configurator is used to configure the stateful precompile when the config is enabled.
 	contract.Configurator
 }
@@ -8,10 +8,14 @@ import (
 	"sort"
 
 	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// pureCacheSize is the number of results cached for each registered pure module.
+const pureCacheSize = 1024
+
 var (
 	// registeredModules is a list of Module to preserve order
 	// for deterministic iteration
@@ -45,6 +49,7 @@ func ReservedAddress(addr common.Address) bool {
 }
 
 // RegisterModule registers a stateful precompile module
+// If the module is pure, its contract is wrapped to cache its results.
 func RegisterModule(stm Module) error {
 	address := stm.Address
 	key := stm.ConfigKey
@@ -64,6 +69,12 @@ func RegisterModule(stm Module) error {
 			return fmt.Errorf("address %s already used by a stateful precompile", address)
 		}
 	}
+	if stm.Pure && len(stm.GasCosts) != 0 {
+		return fmt.Errorf("pure module %s cannot have gas costs overridden by the chain config", key)
+	}
+	if stm.Pure {
+		stm.Contract = contract.NewPureCachedContract(stm.Contract, pureCacheSize)
+	}
 	// sort by address to ensure deterministic iteration
 	registeredModules = insertSortedByAddress(registeredModules, stm)
 	return nil
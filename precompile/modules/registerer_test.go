@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -57,3 +58,48 @@ func TestRegisterModuleInvalidAddresses(t *testing.T) {
 	err = RegisterModule(m)
 	require.ErrorContains(t, err, "not in a reserved range")
 }
+
+// countingContract returns its input and counts how many times it is called.
+type countingContract struct {
+	calls int
+}
+
+func (c *countingContract) Run(_ contract.AccessibleState, _ common.Address, _ common.Address, input []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+	c.calls++
+	return input, suppliedGas, nil
+}
+
+func TestRegisterPureModule(t *testing.T) {
+	registered := registeredModules
+	t.Cleanup(func() { registeredModules = registered })
+	registeredModules = make([]Module, 0)
+
+	pureContract := &countingContract{}
+	pureAddress := common.HexToAddress("0x0300000000000000000000000000000000000001")
+	require.NoError(t, RegisterModule(Module{ConfigKey: "pure", Address: pureAddress, Contract: pureContract, Pure: true}))
+	impureContract := &countingContract{}
+	impureAddress := common.HexToAddress("0x0300000000000000000000000000000000000002")
+	require.NoError(t, RegisterModule(Module{ConfigKey: "impure", Address: impureAddress, Contract: impureContract}))
+	// The gas cost of a pure module must not depend on the chain config.
+	err := RegisterModule(Module{
+		ConfigKey: "pureWithGasCosts",
+		Address:   common.HexToAddress("0x0300000000000000000000000000000000000003"),
+		Contract:  &countingContract{},
+		GasCosts:  map[string]uint64{"count": 1},
+		Pure:      true,
+	})
+	require.ErrorContains(t, err, "pure module pureWithGasCosts cannot have gas costs overridden by the chain config")
+
+	for _, address := range []common.Address{pureAddress, impureAddress} {
+		module, ok := GetPrecompileModuleByAddress(address)
+		require.True(t, ok)
+		for i := 0; i < 2; i++ {
+			ret, _, err := module.Contract.Run(nil, common.Address{}, address, []byte("input"), 0, false)
+			require.NoError(t, err)
+			require.Equal(t, []byte("input"), ret)
+		}
+	}
+	// Only the results of the pure module are cached.
+	require.Equal(t, 1, pureContract.calls)
+	require.Equal(t, 2, impureContract.calls)
+}
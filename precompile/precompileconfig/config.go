@@ -30,6 +30,8 @@ type Config interface {
 	Equal(Config) bool
 	// Verify is called on startup and an error is treated as fatal. Configure can assume the Config has passed verification.
 	Verify(ChainConfig) error
+	// Copy returns a deep copy of the config that shares no memory with the original.
+	Copy() Config
 }
 
 // PredicateContext is the context passed in to the Predicater interface to verify
@@ -88,4 +90,11 @@ type ChainConfig interface {
 	AllowedFeeRecipients() bool
 	// IsDUpgrade returns true if the time is after the DUpgrade.
 	IsDUpgrade(time uint64) bool
+	// GetPrecompileGasCost returns the gas cost of [function] of the precompile with config key [key]
+	// at [timestamp], or [defaultCost] if the chain config does not override it at [timestamp].
+	GetPrecompileGasCost(key string, function string, timestamp uint64, defaultCost uint64) uint64
+	// IsPrecompileEnabled returns true if the precompile at [address] is enabled at [timestamp].
+	// Precompiles can use it through AccessibleState.GetChainConfig to branch on their own activation
+	// or that of another precompile.
+	IsPrecompileEnabled(address common.Address, timestamp uint64) bool
 }
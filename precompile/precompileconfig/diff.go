@@ -0,0 +1,128 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompileconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// unsetField is reported in place of the value of a field that is not set in a config.
+const unsetField = "<unset>"
+
+var emptyList = []byte("[]")
+
+// Diff returns a human-readable description of each field that differs between [a] and [b],
+// which must configure the same precompile. Fields are compared by their JSON encoding, and
+// lists of values (such as allow list addresses) are compared as sets, reporting the values
+// added and removed in [b]. Returns nil if [a] and [b] are Equal.
+func Diff(a, b Config) ([]string, error) {
+	if a.Key() != b.Key() {
+		return nil, fmt.Errorf("cannot diff configs of different precompiles %s and %s", a.Key(), b.Key())
+	}
+	if a.Equal(b) {
+		return nil, nil
+	}
+
+	aFields, err := configFields(a)
+	if err != nil {
+		return nil, err
+	}
+	bFields, err := configFields(b)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(aFields)+len(bFields))
+	for name := range aFields {
+		names = append(names, name)
+	}
+	for name := range bFields {
+		if _, ok := aFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []string
+	for _, name := range names {
+		aValue, aOk := aFields[name]
+		bValue, bOk := bFields[name]
+		// Empty lists are omitted from the encoding, so an unset list is compared as an empty one.
+		switch {
+		case !aOk && isList(bValue):
+			aValue, aOk = emptyList, true
+		case !bOk && isList(aValue):
+			bValue, bOk = emptyList, true
+		}
+		switch {
+		case !aOk:
+			diffs = append(diffs, fmt.Sprintf("%s: %s -> %s", name, unsetField, bValue))
+		case !bOk:
+			diffs = append(diffs, fmt.Sprintf("%s: %s -> %s", name, aValue, unsetField))
+		case !bytes.Equal(aValue, bValue):
+			diffs = append(diffs, diffField(name, aValue, bValue)...)
+		}
+	}
+	return diffs, nil
+}
+
+// configFields returns the compacted JSON encoding of each field of [config].
+func configFields(config Config) (map[string][]byte, error) {
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s config: %w", config.Key(), err)
+	}
+	raw := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(configBytes, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s config: %w", config.Key(), err)
+	}
+	fields := make(map[string][]byte, len(raw))
+	for name, value := range raw {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, value); err != nil {
+			return nil, fmt.Errorf("failed to compact field %s of %s config: %w", name, config.Key(), err)
+		}
+		fields[name] = compacted.Bytes()
+	}
+	return fields, nil
+}
+
+// isList returns true if [value] is an encoded JSON list.
+func isList(value []byte) bool {
+	return len(value) > 0 && value[0] == '['
+}
+
+// diffField describes the change of field [name] from [aValue] to [bValue]. If both values are lists,
+// the elements added and removed are reported rather than the whole lists.
+func diffField(name string, aValue, bValue []byte) []string {
+	var aList, bList []json.RawMessage
+	if json.Unmarshal(aValue, &aList) != nil || json.Unmarshal(bValue, &bList) != nil {
+		return []string{fmt.Sprintf("%s: %s -> %s", name, aValue, bValue)}
+	}
+
+	aSet := make(map[string]struct{}, len(aList))
+	for _, element := range aList {
+		aSet[string(element)] = struct{}{}
+	}
+	bSet := make(map[string]struct{}, len(bList))
+	for _, element := range bList {
+		bSet[string(element)] = struct{}{}
+	}
+
+	var diffs []string
+	for _, element := range bList {
+		if _, ok := aSet[string(element)]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: added %s", name, element))
+		}
+	}
+	for _, element := range aList {
+		if _, ok := bSet[string(element)]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: removed %s", name, element))
+		}
+	}
+	return diffs
+}
@@ -87,6 +87,20 @@ func (m *MockConfig) EXPECT() *MockConfigMockRecorder {
 	return m.recorder
 }
 
+// Copy mocks base method.
+func (m *MockConfig) Copy() Config {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy")
+	ret0, _ := ret[0].(Config)
+	return ret0
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockConfigMockRecorder) Copy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockConfig)(nil).Copy))
+}
+
 // Equal mocks base method.
 func (m *MockConfig) Equal(arg0 Config) bool {
 	m.ctrl.T.Helper()
@@ -208,6 +222,20 @@ func (mr *MockChainConfigMockRecorder) GetFeeConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeConfig", reflect.TypeOf((*MockChainConfig)(nil).GetFeeConfig))
 }
 
+// GetPrecompileGasCost mocks base method.
+func (m *MockChainConfig) GetPrecompileGasCost(arg0, arg1 string, arg2, arg3 uint64) uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrecompileGasCost", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetPrecompileGasCost indicates an expected call of GetPrecompileGasCost.
+func (mr *MockChainConfigMockRecorder) GetPrecompileGasCost(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrecompileGasCost", reflect.TypeOf((*MockChainConfig)(nil).GetPrecompileGasCost), arg0, arg1, arg2, arg3)
+}
+
 // IsDUpgrade mocks base method.
 func (m *MockChainConfig) IsDUpgrade(arg0 uint64) bool {
 	m.ctrl.T.Helper()
@@ -222,6 +250,20 @@ func (mr *MockChainConfigMockRecorder) IsDUpgrade(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDUpgrade", reflect.TypeOf((*MockChainConfig)(nil).IsDUpgrade), arg0)
 }
 
+// IsPrecompileEnabled mocks base method.
+func (m *MockChainConfig) IsPrecompileEnabled(arg0 common.Address, arg1 uint64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPrecompileEnabled", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPrecompileEnabled indicates an expected call of IsPrecompileEnabled.
+func (mr *MockChainConfigMockRecorder) IsPrecompileEnabled(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrecompileEnabled", reflect.TypeOf((*MockChainConfig)(nil).IsPrecompileEnabled), arg0, arg1)
+}
+
 // MockAccepter is a mock of Accepter interface.
 type MockAccepter struct {
 	ctrl     *gomock.Controller
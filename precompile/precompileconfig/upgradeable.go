@@ -3,7 +3,11 @@
 
 package precompileconfig
 
-import "github.com/ava-labs/subnet-evm/utils"
+import (
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/utils"
+)
 
 // Upgrade contains the timestamp for the upgrade along with
 // a boolean [Disable]. If [Disable] is set, the upgrade deactivates
@@ -31,3 +35,40 @@ func (u *Upgrade) Equal(other *Upgrade) bool {
 	}
 	return u.Disable == other.Disable && utils.Uint64PtrEqual(u.BlockTimestamp, other.BlockTimestamp)
 }
+
+// Copy returns a copy of [u] that does not share its BlockTimestamp.
+func (u *Upgrade) Copy() Upgrade {
+	upgrade := Upgrade{Disable: u.Disable}
+	if u.BlockTimestamp != nil {
+		upgrade.BlockTimestamp = utils.NewUint64(*u.BlockTimestamp)
+	}
+	return upgrade
+}
+
+// VerifyUpgrades checks that [configs], the upgrades of a single precompile in activation
+// order, all share the same key, have non-nil and strictly increasing timestamps, and
+// alternate between enabling and disabling the precompile, starting with an enable.
+func VerifyUpgrades(configs []Config) error {
+	var (
+		lastTimestamp *uint64
+		disabled      = true
+	)
+	for i, config := range configs {
+		if key := configs[0].Key(); config.Key() != key {
+			return fmt.Errorf("upgrade at [%d]: key (%s) does not match key (%s) of first upgrade", i, config.Key(), key)
+		}
+		timestamp := config.Timestamp()
+		if timestamp == nil {
+			return fmt.Errorf("upgrade (%s) at [%d]: block timestamp cannot be nil", config.Key(), i)
+		}
+		if lastTimestamp != nil && *timestamp <= *lastTimestamp {
+			return fmt.Errorf("upgrade (%s) at [%d]: block timestamp (%d) <= previous timestamp (%d)", config.Key(), i, *timestamp, *lastTimestamp)
+		}
+		if config.IsDisabled() == disabled {
+			return fmt.Errorf("upgrade (%s) at [%d]: disable should be [%v]", config.Key(), i, !disabled)
+		}
+		lastTimestamp = timestamp
+		disabled = config.IsDisabled()
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// (c) 2023 Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompileconfig
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newMockUpgrade(ctrl *gomock.Controller, key string, timestamp *uint64, disable bool) Config {
+	config := NewMockConfig(ctrl)
+	config.EXPECT().Key().Return(key).AnyTimes()
+	config.EXPECT().Timestamp().Return(timestamp).AnyTimes()
+	config.EXPECT().IsDisabled().Return(disable).AnyTimes()
+	return config
+}
+
+func TestVerifyUpgrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := map[string]struct {
+		configs     []Config
+		expectedErr string
+	}{
+		"no upgrades": {},
+		"enable disable enable": {
+			configs: []Config{
+				newMockUpgrade(ctrl, "a", utils.NewUint64(1), false),
+				newMockUpgrade(ctrl, "a", utils.NewUint64(2), true),
+				newMockUpgrade(ctrl, "a", utils.NewUint64(3), false),
+			},
+		},
+		"nil timestamp": {
+			configs: []Config{
+				newMockUpgrade(ctrl, "a", nil, false),
+			},
+			expectedErr: "block timestamp cannot be nil",
+		},
+		"same timestamp": {
+			configs: []Config{
+				newMockUpgrade(ctrl, "a", utils.NewUint64(1), false),
+				newMockUpgrade(ctrl, "a", utils.NewUint64(1), true),
+			},
+			expectedErr: "block timestamp (1) <= previous timestamp (1)",
+		},
+		"decreasing timestamp": {
+			configs: []Config{
+				newMockUpgrade(ctrl, "a", utils.NewUint64(2), false),
+				newMockUpgrade(ctrl, "a", utils.NewUint64(1), true),
+			},
+			expectedErr: "block timestamp (1) <= previous timestamp (2)",
+		},
+		"disable first": {
+			configs: []Config{
+				newMockUpgrade(ctrl, "a", utils.NewUint64(1), true),
+			},
+			expectedErr: "disable should be [false]",
+		},
+		"disable twice": {
+			configs: []Config{
+				newMockUpgrade(ctrl, "a", utils.NewUint64(1), false),
+				newMockUpgrade(ctrl, "a", utils.NewUint64(2), true),
+				newMockUpgrade(ctrl, "a", utils.NewUint64(3), true),
+			},
+			expectedErr: "disable should be [false]",
+		},
+		"different keys": {
+			configs: []Config{
+				newMockUpgrade(ctrl, "a", utils.NewUint64(1), false),
+				newMockUpgrade(ctrl, "b", utils.NewUint64(2), true),
+			},
+			expectedErr: "key (b) does not match key (a)",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyUpgrades(test.configs)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/x/warp"
+)
+
+func TestRegisteredModulesConformance(t *testing.T) {
+	selectorDispatched := map[string]bool{
+		// The allow list precompiles dispatch on the selectors of the allow list functions.
+		deployerallowlist.ConfigKey: true,
+		txallowlist.ConfigKey:       true,
+		// The native minter dispatches on the selectors of mintNativeCoin and the allow list functions.
+		nativeminter.ConfigKey: true,
+		// The fee manager dispatches on the selectors of its fee config getters and setters and the allow list functions.
+		feemanager.ConfigKey: true,
+		// The reward manager dispatches on the selectors of its reward address functions and the allow list functions.
+		rewardmanager.ConfigKey: true,
+		// Warp dispatches on the selectors of sendWarpMessage and the getters of verified messages.
+		warp.ConfigKey: true,
+	}
+	testutils.RunConformanceTests(t, state.NewTestStateDB, selectorDispatched)
+}
@@ -0,0 +1,78 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const hashCost = 100
+
+var errEmptyInput = errors.New("empty input")
+
+// hashPrecompile returns the keccak256 hash of its input and counts how many times it is computed.
+type hashPrecompile struct {
+	calls int
+}
+
+func (p *hashPrecompile) Run(_ contract.AccessibleState, _ common.Address, _ common.Address, input []byte, suppliedGas uint64, _ bool) ([]byte, uint64, error) {
+	p.calls++
+	remainingGas, err := contract.DeductGas(suppliedGas, hashCost)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(input) == 0 {
+		return nil, remainingGas, errEmptyInput
+	}
+	return crypto.Keccak256(input), remainingGas, nil
+}
+
+func TestPureCachedContract(t *testing.T) {
+	precompile := &hashPrecompile{}
+	module := modules.Module{
+		ConfigKey: "hashConfig",
+		Address:   common.HexToAddress("0x0300000000000000000000000000000000000ffe"),
+		Contract:  contract.NewPureCachedContract(precompile, 16),
+		Pure:      true,
+	}
+	input := []byte("input")
+	expectedRes := crypto.Keccak256(input)
+
+	test := PrecompileTest{
+		Input:           input,
+		SuppliedGas:     hashCost,
+		ExpectedRes:     expectedRes,
+		ExpectedGasUsed: hashCost,
+		Steps: []PrecompileStep{
+			// Cached results still charge the gas used by the first call, and fail with
+			// ErrOutOfGas on a cache hit with less gas.
+			{Input: input, SuppliedGas: hashCost, ExpectedRes: expectedRes},
+			{Input: input, SuppliedGas: hashCost - 1, ExpectedErrIs: vmerrs.ErrOutOfGas},
+			// Failed calls are not cached.
+			{Input: []byte{}, SuppliedGas: hashCost, ExpectedErrIs: errEmptyInput},
+			{Input: []byte{}, SuppliedGas: hashCost, ExpectedErrIs: errEmptyInput},
+		},
+	}
+	test.Run(t, module, state.NewTestStateDB(t))
+	require.Equal(t, 3, precompile.calls)
+
+	// The result is not shared with the caller, and gas beyond the cost of the call is left over.
+	ret, remainingGas, err := module.Contract.Run(nil, common.Address{}, module.Address, input, 2*hashCost, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(hashCost), remainingGas)
+	ret[0]++
+	ret, _, err = module.Contract.Run(nil, common.Address{}, module.Address, input, hashCost, false)
+	require.NoError(t, err)
+	require.Equal(t, expectedRes, ret)
+	require.Equal(t, 3, precompile.calls)
+}
@@ -4,9 +4,11 @@
 package testutils
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -61,3 +63,17 @@ func RunEqualTests(t *testing.T, tests map[string]ConfigEqualTest) {
 		})
 	}
 }
+
+// AssertConfigRoundTrip marshals [cfg] to JSON and unmarshals it into a config made by [configurator],
+// as the node does when loading precompile configs, and asserts that the result is Equal to [cfg].
+// It catches config fields that are not marshalled, such as fields missing a JSON tag.
+func AssertConfigRoundTrip(t testing.TB, configurator contract.Configurator, cfg precompileconfig.Config) {
+	t.Helper()
+
+	cfgBytes, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	decoded := configurator.MakeConfig()
+	require.NoError(t, json.Unmarshal(cfgBytes, decoded))
+	require.Equal(t, cfg.Key(), decoded.Key())
+	require.True(t, cfg.Equal(decoded), "config does not round trip through JSON: %s", cfgBytes)
+}
@@ -0,0 +1,60 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// RunConformanceTests runs a minimal set of checks against every registered module, so that mistakes
+// made when instantiating a precompile from the template are caught for all precompiles at once.
+// Callers must import the precompiles to check, typically through the precompile registry.
+// For each module it checks that:
+//   - calling the contract with empty input and no gas fails with vmerrs.ErrOutOfGas within DefaultRunTimeout without
+//     panicking or refunding gas. The modules whose config keys are in [selectorDispatched] dispatch on a function
+//     selector and reject empty input before charging gas, so any error is accepted from them.
+//   - the configurator rejects a config of the wrong type
+func RunConformanceTests(t *testing.T, newStateDB func(t testing.TB) contract.StateDB, selectorDispatched map[string]bool) {
+	t.Helper()
+
+	registeredModules := modules.RegisteredModules()
+	require.NotEmpty(t, registeredModules, "no modules registered")
+	for _, module := range registeredModules {
+		module := module
+		t.Run(module.ConfigKey, func(t *testing.T) {
+			t.Run("empty input without gas", func(t *testing.T) {
+				runParams := PrecompileTest{}.setup(t, module, newStateDB(t))
+				var (
+					remainingGas uint64
+					err          error
+				)
+				require.NotPanics(t, func() {
+					_, remainingGas, err = runWithTimeout(t, 0, module, runParams, nil, 0, false)
+				})
+				if selectorDispatched[module.ConfigKey] {
+					require.Error(t, err)
+				} else {
+					require.ErrorIs(t, err, vmerrs.ErrOutOfGas)
+				}
+				require.Zero(t, remainingGas)
+			})
+
+			t.Run("wrong config type", func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				chainConfig := precompileconfig.NewMockChainConfig(ctrl)
+				wrongConfig := precompileconfig.NewMockConfig(ctrl)
+				blockContext := contract.NewMockBlockContext(ctrl)
+				err := module.Configure(chainConfig, wrongConfig, newStateDB(t), blockContext)
+				require.Error(t, err)
+			})
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzSeedGas is the gas supplied alongside each seed input added to the corpus.
+const fuzzSeedGas uint64 = 1_000_000
+
+// FuzzPrecompile fuzzes [module] with arbitrary inputs and gas amounts, after seeding the corpus with [seeds].
+// It only asserts that the precompile never panics and never returns more gas than was supplied.
+func FuzzPrecompile(f *testing.F, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, seeds [][]byte) {
+	f.Helper()
+
+	for _, seed := range seeds {
+		f.Add(seed, fuzzSeedGas)
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte, suppliedGas uint64) {
+		runParams := PrecompileTest{}.setup(t, module, newStateDB(t))
+
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("precompile panicked with input %s and gas %d: %v", common.Bytes2Hex(input), suppliedGas, r)
+			}
+		}()
+
+		_, remainingGas, _ := module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, input, suppliedGas, false)
+		require.LessOrEqual(t, remainingGas, suppliedGas, "precompile returned more gas than supplied with input %s", common.Bytes2Hex(input))
+	})
+}
@@ -0,0 +1,77 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/stretchr/testify/require"
+)
+
+// RunGasScalingTest measures the gas used by [test] with the input returned by [inputFn] for each of [sizes],
+// which must be increasing, and asserts that the gas used never decreases as the input grows and that the
+// largest input costs more than the smallest. Each call must succeed with the SuppliedGas of [test], and the
+// Input and InputFn of [test] are ignored.
+// The gas used is fitted to a linear function of the input length in bytes. If [tolerance] is positive, the gas
+// used by each call must be within [tolerance] of the fitted gas, as a fraction of it.
+// Returns the fitted gas cost per input byte, which is also logged.
+func RunGasScalingTest(t *testing.T, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, test PrecompileTest, inputFn func(t testing.TB, size int) []byte, sizes []int, tolerance float64) float64 {
+	t.Helper()
+	require.GreaterOrEqual(t, len(sizes), 2, "gas scaling requires at least 2 input sizes")
+
+	test.InputFn = nil
+	test.InputFnErr = nil
+	lengths := make([]float64, len(sizes))
+	gasUsed := make([]float64, len(sizes))
+	for i, size := range sizes {
+		if i > 0 {
+			require.Greater(t, size, sizes[i-1], "input sizes must be increasing")
+		}
+		test.Input = inputFn(t, size)
+		used, _, err := test.Measure(t, module, newStateDB(t))
+		require.NoError(t, err, "input size %d", size)
+
+		lengths[i] = float64(len(test.Input))
+		gasUsed[i] = float64(used)
+		if i > 0 {
+			require.GreaterOrEqual(t, gasUsed[i], gasUsed[i-1], "gas used decreased from input size %d to %d", sizes[i-1], size)
+		}
+	}
+	require.Greater(t, gasUsed[len(sizes)-1], gasUsed[0], "gas used does not grow with input size")
+
+	costPerByte, baseCost := fitLine(lengths, gasUsed)
+	t.Logf("%s gas cost per input byte: %.2f, base cost: %.0f", module.ConfigKey, costPerByte, baseCost)
+	if tolerance > 0 {
+		for i, size := range sizes {
+			fitted := baseCost + costPerByte*lengths[i]
+			require.LessOrEqual(t, math.Abs(gasUsed[i]-fitted), tolerance*fitted, "gas used %.0f with input size %d is not within tolerance of fitted gas %.0f", gasUsed[i], size, fitted)
+		}
+	}
+	return costPerByte
+}
+
+// fitLine returns the slope and intercept of the least squares line through the points ([xs], [ys]).
+func fitLine(xs, ys []float64) (slope float64, intercept float64) {
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(len(xs))
+	meanY /= float64(len(ys))
+
+	var covariance, variance float64
+	for i := range xs {
+		covariance += (xs[i] - meanX) * (ys[i] - meanY)
+		variance += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+	if variance == 0 {
+		return 0, meanY
+	}
+	slope = covariance / variance
+	return slope, meanY - slope*meanX
+}
@@ -5,10 +5,14 @@ package testutils
 
 import (
 	"math/big"
+	"runtime"
+	"runtime/debug"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
@@ -18,19 +22,38 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// DefaultRunTimeout is the time a precompile call in a PrecompileTest may take before the test fails,
+// if Timeout is not specified.
+const DefaultRunTimeout = 5 * time.Second
+
 // PrecompileTest is a test case for a precompile
 type PrecompileTest struct {
 	// Caller is the address of the precompile caller
 	Caller common.Address
+	// ContractAddress is the address the precompile is called at.
+	// If nil, the module's address is used. Config is still applied by the module's
+	// configurator, which always uses the module's own address.
+	ContractAddress *common.Address
 	// Input the raw input bytes to the precompile
 	Input []byte
 	// InputFn is a function that returns the raw input bytes to the precompile
 	// If specified, Input will be ignored.
 	InputFn func(t testing.TB) []byte
+	// InputFnErr is a function that returns the raw input bytes to the precompile, or an error
+	// if the input cannot be built. If specified, Input and InputFn will be ignored.
+	// If it returns an error, Run checks the error against ExpectedErr and ExpectedErrIs
+	// instead of calling the precompile.
+	InputFnErr func(t testing.TB) ([]byte, error)
 	// SuppliedGas is the amount of gas supplied to the precompile
 	SuppliedGas uint64
+	// Value is the amount of native coin attached to the call.
+	// AccessibleState does not expose the call value, so as in the EVM the value is
+	// credited to the contract address before the precompile is called, where the
+	// precompile can observe it through its balance. The caller's balance is not debited.
+	Value *big.Int
 	// ReadOnly is whether the precompile should be called in read only
-	// mode. If true, the precompile should not modify the state.
+	// mode. If true, the precompile should not modify the state and the
+	// test fails if it does.
 	ReadOnly bool
 	// Config is the config to use for the precompile
 	// It should be the same precompile config that is used in the
@@ -43,13 +66,83 @@ type PrecompileTest struct {
 	SetupBlockContext func(*contract.MockBlockContext)
 	// AfterHook is called after the precompile is called.
 	AfterHook func(t testing.TB, state contract.StateDB)
+	// CaptureHook is called right before the precompile is called, after BeforeHook and Config
+	// have been applied. Its return value is passed to CompareHook, so that the test can assert
+	// on the change in state made by the precompile.
+	CaptureHook func(t testing.TB, state contract.StateDB) any
+	// CompareHook is called after AfterHook with the value returned by CaptureHook, or nil
+	// if CaptureHook is not specified.
+	CompareHook func(t testing.TB, state contract.StateDB, captured any)
 	// ExpectedRes is the expected raw byte result returned by the precompile
 	ExpectedRes []byte
+	// ExpectedResABI are the ABI outputs the result returned by the precompile is expected to decode with.
+	// If set, the result must unpack into ExpectedResValues and be the canonical encoding of those values,
+	// which catches results with the right length but the wrong structure.
+	ExpectedResABI abi.Arguments
+	// ExpectedResValues are the Go values the result is expected to unpack into with ExpectedResABI.
+	// *big.Int values are compared by value.
+	ExpectedResValues []any
 	// ExpectedErr is the expected error returned by the precompile
 	ExpectedErr string
+	// ExpectedErrIs is the expected error returned by the precompile, compared with errors.Is.
+	// It can be used together with ExpectedErr.
+	ExpectedErrIs error
+	// ExpectedGasUsed is the expected amount of gas consumed by the precompile.
+	// If non-zero, it is compared against SuppliedGas - remainingGas.
+	ExpectedGasUsed uint64
+	// ExpectedRemainingGas is the expected amount of gas remaining after the precompile is called.
+	// If neither ExpectedGasUsed nor ExpectedRemainingGas is specified, the remaining gas is expected to be 0.
+	ExpectedRemainingGas *uint64
+	// ExpectedRefund is the expected change in the gas refund counter of the state from calling the precompile
+	// with Input, so 0 asserts the precompile issues no refund. If nil, the refund counter is not checked.
+	// contract.StateDB does not expose the refund counter, so it is read from the state passed to Run, which
+	// must have a GetRefund method like state.StateDB.
+	ExpectedRefund *uint64
 	// ChainConfig is the chain config to use for the precompile's block context
 	// If nil, the default chain config will be used.
 	ChainConfig precompileconfig.ChainConfig
+	// ConfigureChainConfig sets the expected calls on the default MockChainConfig, such as
+	// fork activation times. Expectations set here take precedence over the defaults.
+	// Ignored if ChainConfig is specified.
+	ConfigureChainConfig func(*precompileconfig.MockChainConfig)
+	// Steps is an optional sequence of calls to the precompile that are executed in order
+	// against the same state after Input (if specified). Execution stops at the first step
+	// that does not match its expectations. AfterHook is called after the last step.
+	Steps []PrecompileStep
+	// ExpectedLogs are the logs expected to be emitted by the precompile during the test.
+	// If Address is left empty, it defaults to the address of the precompile.
+	// If nil, emitted logs are not checked.
+	ExpectedLogs []Log
+	// AccessHook is called after the precompile is called with the storage slots read and
+	// written by the precompile, in the order they were accessed. Accesses made during
+	// configuration or by BeforeHook and CaptureHook are not included. If nil, accesses are not recorded.
+	AccessHook func(t testing.TB, accesses []StateAccess)
+	// Timeout is the time each call to the precompile may take before the test fails,
+	// so that a precompile that never returns does not stall the test binary.
+	// If zero, DefaultRunTimeout is used.
+	Timeout time.Duration
+}
+
+// PrecompileStep is a single call to the precompile within a PrecompileTest
+type PrecompileStep struct {
+	// Caller is the address of the caller of this step, so that a scenario can involve several
+	// actors. If nil, the Caller of the PrecompileTest is used.
+	Caller *common.Address
+	// Input the raw input bytes to the precompile
+	Input []byte
+	// SuppliedGas is the amount of gas supplied to the precompile
+	SuppliedGas uint64
+	// Value is the amount of native coin attached to the call of this step.
+	// It is credited to the contract address as for the Value of the PrecompileTest.
+	Value *big.Int
+	// ReadOnly is whether the precompile should be called in read only mode.
+	ReadOnly bool
+	// ExpectedRes is the expected raw byte result returned by the precompile
+	ExpectedRes []byte
+	// ExpectedErr is the expected error returned by the precompile
+	ExpectedErr string
+	// ExpectedErrIs is the expected error returned by the precompile, compared with errors.Is.
+	ExpectedErrIs error
 }
 
 type PrecompileRunparams struct {
@@ -58,31 +151,222 @@ type PrecompileRunparams struct {
 	ContractAddress common.Address
 	Input           []byte
 	SuppliedGas     uint64
+	Value           *big.Int
 	ReadOnly        bool
+
+	// inputErr is the error returned by InputFnErr, if any.
+	inputErr error
+}
+
+// PrecompileResult is the outcome of calling the precompile with the Input of a PrecompileTest.
+type PrecompileResult struct {
+	Ret     []byte
+	GasUsed uint64
+	Err     error
 }
 
 func (test PrecompileTest) Run(t *testing.T, module modules.Module, state contract.StateDB) {
+	test.RunResult(t, module, state)
+}
+
+// RunResult runs the test as Run does and returns the result of calling the precompile with Input,
+// so that it can be reported or compared by the caller. If InputFnErr fails, its error is returned
+// in Err. The result is empty if the test has no Input.
+func (test PrecompileTest) RunResult(t *testing.T, module modules.Module, state contract.StateDB) PrecompileResult {
+	var refunds refundReader
+	if test.ExpectedRefund != nil {
+		var ok bool
+		refunds, ok = state.(refundReader)
+		require.True(t, ok, "ExpectedRefund requires a state with a GetRefund method, got %T", state)
+	}
+
+	var logRecorder *logRecorderStateDB
+	if test.ExpectedLogs != nil {
+		logRecorder = &logRecorderStateDB{StateDB: state}
+		state = logRecorder
+	}
+
+	var accessRecorder *accessRecorderStateDB
+	if test.AccessHook != nil {
+		accessRecorder = &accessRecorderStateDB{StateDB: state}
+		state = accessRecorder
+	}
+
+	// Wrap the state to catch any writes performed by the precompile in read only mode.
+	writeRecorder := &writeRecorderStateDB{StateDB: state}
+	state = writeRecorder
+
 	runParams := test.setup(t, module, state)
+	if runParams.inputErr != nil {
+		checkErr(t, runParams.inputErr, test.ExpectedErr, test.ExpectedErrIs, "failed to build input")
+		return PrecompileResult{Err: runParams.inputErr}
+	}
+	if logRecorder != nil {
+		// Ignore any logs added during configuration or by the BeforeHook.
+		logRecorder.logs = nil
+	}
+	var captured any
+	if test.CaptureHook != nil {
+		captured = test.CaptureHook(t, state)
+	}
+	if accessRecorder != nil {
+		// Ignore any accesses made during configuration or by the BeforeHook and CaptureHook.
+		accessRecorder.accesses = nil
+	}
 
+	var result PrecompileResult
 	if runParams.Input != nil {
-		ret, remainingGas, err := module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
-		if len(test.ExpectedErr) != 0 {
-			require.ErrorContains(t, err, test.ExpectedErr)
-		} else {
-			require.NoError(t, err)
+		writeRecorder.writes = nil
+		var refundBefore uint64
+		if refunds != nil {
+			refundBefore = refunds.GetRefund()
 		}
-		require.Equal(t, uint64(0), remainingGas)
+		ret, remainingGas, err := runWithTimeout(t, test.Timeout, module, runParams, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
+		if refunds != nil {
+			require.Equal(t, refundBefore+*test.ExpectedRefund, refunds.GetRefund(), "unexpected gas refund counter after calling the precompile")
+		}
+		if runParams.ReadOnly {
+			require.Empty(t, writeRecorder.writes, "precompile modified state in read only mode")
+		}
+		checkErr(t, err, test.ExpectedErr, test.ExpectedErrIs)
+		test.checkGas(t, runParams.SuppliedGas, remainingGas)
 		require.Equal(t, test.ExpectedRes, ret)
+		if test.ExpectedResABI != nil {
+			checkResABI(t, test.ExpectedResABI, test.ExpectedResValues, ret)
+		}
+		result = PrecompileResult{
+			Ret:     ret,
+			GasUsed: runParams.SuppliedGas - remainingGas,
+			Err:     err,
+		}
+	}
+
+	for i, step := range test.Steps {
+		stepParams := runParams
+		if step.Caller != nil {
+			stepParams.Caller = *step.Caller
+		}
+		stepParams.Value = step.Value
+		creditValue(state, stepParams.ContractAddress, step.Value)
+		writeRecorder.writes = nil
+		ret, remainingGas, err := runWithTimeout(t, test.Timeout, module, stepParams, step.Input, step.SuppliedGas, step.ReadOnly)
+		if step.ReadOnly {
+			require.Empty(t, writeRecorder.writes, "precompile modified state in read only mode in step %d", i)
+		}
+		checkErr(t, err, step.ExpectedErr, step.ExpectedErrIs, "step %d", i)
+		require.Equal(t, uint64(0), remainingGas, "step %d", i)
+		require.Equal(t, step.ExpectedRes, ret, "step %d", i)
+	}
+
+	if logRecorder != nil {
+		test.checkLogs(t, runParams.ContractAddress, logRecorder.logs)
+	}
+	if accessRecorder != nil {
+		test.AccessHook(t, accessRecorder.accesses)
 	}
 
 	if test.AfterHook != nil {
 		test.AfterHook(t, state)
 	}
+	if test.CompareHook != nil {
+		test.CompareHook(t, state, captured)
+	}
+	return result
+}
+
+// refundReader is implemented by states that expose their gas refund counter, such as state.StateDB.
+type refundReader interface {
+	GetRefund() uint64
+}
+
+// checkResABI asserts that [ret] unpacks with [outputs] into [expectedValues], and that packing the
+// unpacked values reproduces [ret] exactly, so there are no trailing or non-canonical bytes.
+func checkResABI(t testing.TB, outputs abi.Arguments, expectedValues []any, ret []byte) {
+	t.Helper()
+
+	values, err := outputs.Unpack(ret)
+	require.NoError(t, err, "result does not decode with the expected ABI outputs")
+	require.Len(t, values, len(expectedValues), "unexpected number of decoded result values")
+	for i, value := range values {
+		expectedInt, ok := expectedValues[i].(*big.Int)
+		if !ok {
+			require.Equal(t, expectedValues[i], value, "decoded result value %d", i)
+			continue
+		}
+		valueInt, ok := value.(*big.Int)
+		require.True(t, ok, "decoded result value %d is %T, not *big.Int", i, value)
+		require.Zero(t, expectedInt.Cmp(valueInt), "decoded result value %d is %s, expected %s", i, valueInt, expectedInt)
+	}
+
+	packed, err := outputs.Pack(values...)
+	require.NoError(t, err)
+	require.Equal(t, packed, ret, "result is not the canonical encoding of its decoded values")
+}
+
+// runWithTimeout calls the precompile of [module] with [input] in a separate goroutine, failing the test
+// if the call does not return within [timeout], or DefaultRunTimeout if [timeout] is zero.
+// The goroutine of a call that times out is leaked, as it cannot be stopped.
+// A panic in the precompile fails the test with the panic value and the stack of the panicking goroutine.
+func runWithTimeout(t testing.TB, timeout time.Duration, module modules.Module, runParams PrecompileRunparams, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+	t.Helper()
+
+	if timeout == 0 {
+		timeout = DefaultRunTimeout
+	}
+	type runResult struct {
+		ret          []byte
+		remainingGas uint64
+		err          error
+		panicked     any
+		stack        []byte
+	}
+	resultChan := make(chan runResult, 1)
+	go func() {
+		var result runResult
+		defer func() {
+			if result.panicked = recover(); result.panicked != nil {
+				result.stack = debug.Stack()
+			}
+			resultChan <- result
+		}()
+		result.ret, result.remainingGas, result.err = module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, input, suppliedGas, readOnly)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case result := <-resultChan:
+		if result.panicked != nil {
+			t.Fatalf("precompile %s panicked: %v\n%s", module.ConfigKey, result.panicked, result.stack)
+			return nil, 0, nil
+		}
+		return result.ret, result.remainingGas, result.err
+	case <-timer.C:
+		t.Fatalf("precompile %s did not return within %s", module.ConfigKey, timeout)
+		return nil, 0, nil
+	}
+}
+
+// Measure runs the setup and calls the precompile with Input, returning the gas used and the
+// results of the call instead of asserting them against the test's expectations.
+// Steps, expected logs and AfterHook are ignored. If InputFnErr fails, its error is returned.
+func (test PrecompileTest) Measure(t testing.TB, module modules.Module, state contract.StateDB) (gasUsed uint64, ret []byte, err error) {
+	t.Helper()
+
+	runParams := test.setup(t, module, state)
+	if runParams.inputErr != nil {
+		return 0, nil, runParams.inputErr
+	}
+	ret, remainingGas, err := module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
+	return runParams.SuppliedGas - remainingGas, ret, err
 }
 
 func (test PrecompileTest) setup(t testing.TB, module modules.Module, state contract.StateDB) PrecompileRunparams {
 	t.Helper()
 	contractAddress := module.Address
+	if test.ContractAddress != nil {
+		contractAddress = *test.ContractAddress
+	}
 
 	ctrl := gomock.NewController(t)
 
@@ -93,9 +377,18 @@ func (test PrecompileTest) setup(t testing.TB, module modules.Module, state cont
 	chainConfig := test.ChainConfig
 	if chainConfig == nil {
 		mockChainConfig := precompileconfig.NewMockChainConfig(ctrl)
+		// gomock matches expectations in the order they were added, so the test
+		// specific expectations must be set before the defaults.
+		if test.ConfigureChainConfig != nil {
+			test.ConfigureChainConfig(mockChainConfig)
+		}
 		mockChainConfig.EXPECT().GetFeeConfig().AnyTimes().Return(commontype.ValidTestFeeConfig)
 		mockChainConfig.EXPECT().AllowedFeeRecipients().AnyTimes().Return(false)
 		mockChainConfig.EXPECT().IsDUpgrade(gomock.Any()).AnyTimes().Return(true)
+		mockChainConfig.EXPECT().IsPrecompileEnabled(gomock.Any(), gomock.Any()).AnyTimes().Return(true)
+		mockChainConfig.EXPECT().GetPrecompileGasCost(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(_ string, _ string, _ uint64, defaultCost uint64) uint64 { return defaultCost },
+		)
 		chainConfig = mockChainConfig
 	}
 
@@ -120,22 +413,90 @@ func (test PrecompileTest) setup(t testing.TB, module modules.Module, state cont
 	}
 
 	input := test.Input
-	if test.InputFn != nil {
+	var inputErr error
+	switch {
+	case test.InputFnErr != nil:
+		input, inputErr = test.InputFnErr(t)
+	case test.InputFn != nil:
 		input = test.InputFn(t)
 	}
 
+	creditValue(state, contractAddress, test.Value)
+
 	return PrecompileRunparams{
 		AccessibleState: accessibleState,
 		Caller:          test.Caller,
 		ContractAddress: contractAddress,
 		Input:           input,
 		SuppliedGas:     test.SuppliedGas,
+		Value:           test.Value,
 		ReadOnly:        test.ReadOnly,
+		inputErr:        inputErr,
+	}
+}
+
+// creditValue credits [value] attached to a call to the precompile at [contractAddress], as the EVM
+// does before calling the precompile.
+func creditValue(state contract.StateDB, contractAddress common.Address, value *big.Int) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+	if !state.Exist(contractAddress) {
+		state.CreateAccount(contractAddress)
+	}
+	state.AddBalance(contractAddress, value)
+}
+
+// checkGas verifies [remainingGas] against the gas expectations of the test.
+// If no expectation is specified, all of [suppliedGas] is expected to be consumed.
+func (test PrecompileTest) checkGas(t testing.TB, suppliedGas uint64, remainingGas uint64) {
+	t.Helper()
+
+	if test.ExpectedGasUsed == 0 && test.ExpectedRemainingGas == nil {
+		require.Equal(t, uint64(0), remainingGas)
+		return
+	}
+	if test.ExpectedGasUsed != 0 {
+		require.Equal(t, test.ExpectedGasUsed, suppliedGas-remainingGas, "unexpected gas used")
+	}
+	if test.ExpectedRemainingGas != nil {
+		require.Equal(t, *test.ExpectedRemainingGas, remainingGas, "unexpected remaining gas")
+	}
+}
+
+// checkErr verifies [err] against [expectedErr] and [expectedErrIs].
+// If neither is specified, [err] is expected to be nil.
+func checkErr(t testing.TB, err error, expectedErr string, expectedErrIs error, msgAndArgs ...interface{}) {
+	t.Helper()
+
+	if len(expectedErr) == 0 && expectedErrIs == nil {
+		require.NoError(t, err, msgAndArgs...)
+		return
+	}
+	if len(expectedErr) != 0 {
+		require.ErrorContains(t, err, expectedErr, msgAndArgs...)
+	}
+	if expectedErrIs != nil {
+		require.ErrorIs(t, err, expectedErrIs, msgAndArgs...)
+	}
+}
+
+// checkLogs compares [logs] against the expected logs of the test.
+func (test PrecompileTest) checkLogs(t testing.TB, contractAddress common.Address, logs []Log) {
+	t.Helper()
+
+	require.Len(t, logs, len(test.ExpectedLogs))
+	for i, expected := range test.ExpectedLogs {
+		if expected.Address == (common.Address{}) {
+			expected.Address = contractAddress
+		}
+		require.Equal(t, expected, logs[i], "log %d", i)
 	}
 }
 
 func (test PrecompileTest) Bench(b *testing.B, module modules.Module, state contract.StateDB) {
 	runParams := test.setup(b, module, state)
+	require.NoError(b, runParams.inputErr)
 
 	if runParams.Input == nil {
 		b.Skip("Skipping precompile benchmark due to nil input (used for configuration tests)")
@@ -145,23 +506,96 @@ func (test PrecompileTest) Bench(b *testing.B, module modules.Module, state cont
 	snapshot := stateDB.Snapshot()
 
 	ret, remainingGas, err := module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
-	if len(test.ExpectedErr) != 0 {
-		require.ErrorContains(b, err, test.ExpectedErr)
-	} else {
-		require.NoError(b, err)
+	checkErr(b, err, test.ExpectedErr, test.ExpectedErrIs)
+	test.checkGas(b, runParams.SuppliedGas, remainingGas)
+	require.Equal(b, test.ExpectedRes, ret)
+
+	if test.AfterHook != nil {
+		test.AfterHook(b, state)
 	}
-	require.Equal(b, uint64(0), remainingGas)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	elapsed, snapshot := benchLoop(b.N, module, runParams, stateDB, snapshot)
+	b.StopTimer()
+
+	b.ReportMetric(float64(runParams.SuppliedGas), "gas/op")
+	b.ReportMetric(mgasPerSecond(runParams.SuppliedGas, b.N, elapsed), "mgas/s")
+
+	allocs, bytes, snapshot := allocsPerCall(module, runParams, stateDB, snapshot)
+	b.ReportMetric(allocs, "allocs/call")
+	b.ReportMetric(bytes, "bytes/call")
+
+	// Execute the test one final time to ensure that if our RevertToSnapshot logic breaks such that each run is actually failing or resulting in unexpected behavior
+	// the benchmark should catch the error here.
+	stateDB.RevertToSnapshot(snapshot)
+	ret, remainingGas, err = module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
+	checkErr(b, err, test.ExpectedErr, test.ExpectedErrIs)
+	test.checkGas(b, runParams.SuppliedGas, remainingGas)
 	require.Equal(b, test.ExpectedRes, ret)
 
 	if test.AfterHook != nil {
 		test.AfterHook(b, state)
 	}
+}
+
+// CompareBench benchmarks [moduleA] and [moduleB] with the same test input, each against its own
+// state from [newStateDB]. It reports the gas used and throughput of both modules, along with the
+// relative throughput change of [moduleB] over [moduleA] as "delta-%".
+// Both modules must produce the expected error and result, but their gas usage may differ.
+func (test PrecompileTest) CompareBench(b *testing.B, moduleA, moduleB modules.Module, newStateDB func(t testing.TB) contract.StateDB) {
+	runParamsA := test.setup(b, moduleA, newStateDB(b))
+	require.NoError(b, runParamsA.inputErr)
+	if runParamsA.Input == nil {
+		b.Skip("Skipping precompile benchmark due to nil input (used for configuration tests)")
+	}
+	runParamsB := test.setup(b, moduleB, newStateDB(b))
+
+	stateDBA := runParamsA.AccessibleState.GetStateDB()
+	stateDBB := runParamsB.AccessibleState.GetStateDB()
+	snapshotA := stateDBA.Snapshot()
+	snapshotB := stateDBB.Snapshot()
+
+	gasUsedA := test.compareBenchRun(b, "A", moduleA, runParamsA)
+	gasUsedB := test.compareBenchRun(b, "B", moduleB, runParamsB)
 
 	b.ReportAllocs()
-	start := time.Now()
 	b.ResetTimer()
+	elapsedA, snapshotA := benchLoop(b.N, moduleA, runParamsA, stateDBA, snapshotA)
+	elapsedB, snapshotB := benchLoop(b.N, moduleB, runParamsB, stateDBB, snapshotB)
+	b.StopTimer()
 
-	for i := 0; i < b.N; i++ {
+	mgaspsA := mgasPerSecond(gasUsedA, b.N, elapsedA)
+	mgaspsB := mgasPerSecond(gasUsedB, b.N, elapsedB)
+	b.ReportMetric(float64(gasUsedA), "a-gas/op")
+	b.ReportMetric(float64(gasUsedB), "b-gas/op")
+	b.ReportMetric(mgaspsA, "a-mgas/s")
+	b.ReportMetric(mgaspsB, "b-mgas/s")
+	if mgaspsA > 0 {
+		b.ReportMetric(100*(mgaspsB-mgaspsA)/mgaspsA, "delta-%")
+	}
+
+	// As in Bench, execute both modules one final time to catch broken RevertToSnapshot logic.
+	stateDBA.RevertToSnapshot(snapshotA)
+	stateDBB.RevertToSnapshot(snapshotB)
+	test.compareBenchRun(b, "A", moduleA, runParamsA)
+	test.compareBenchRun(b, "B", moduleB, runParamsB)
+}
+
+// compareBenchRun executes [module] once, verifies the error and result against the test's
+// expectations and returns the gas used.
+func (test PrecompileTest) compareBenchRun(b *testing.B, name string, module modules.Module, runParams PrecompileRunparams) uint64 {
+	ret, remainingGas, err := module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
+	checkErr(b, err, test.ExpectedErr, test.ExpectedErrIs, "module %s", name)
+	require.Equal(b, test.ExpectedRes, ret, "module %s", name)
+	return runParams.SuppliedGas - remainingGas
+}
+
+// benchLoop executes [module] [n] times, reverting [stateDB] to [snapshot] before each run so that
+// every run starts from the same state. It returns the elapsed time and the latest snapshot.
+func benchLoop(n int, module modules.Module, runParams PrecompileRunparams, stateDB contract.StateDB, snapshot int) (time.Duration, int) {
+	start := time.Now()
+	for i := 0; i < n; i++ {
 		// Revert to the previous snapshot and take a new snapshot, so we can reset the state after execution
 		stateDB.RevertToSnapshot(snapshot)
 		snapshot = stateDB.Snapshot()
@@ -169,40 +603,101 @@ func (test PrecompileTest) Bench(b *testing.B, module modules.Module, state cont
 		// Ignore return values for benchmark
 		_, _, _ = module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
 	}
-	b.StopTimer()
+	return time.Since(start), snapshot
+}
+
+// allocRuns is the number of precompile calls averaged over by allocsPerCall.
+const allocRuns = 100
+
+// allocsPerCall returns the average number of allocations and allocated bytes of a single call to [module],
+// measured with testing.AllocsPerRun. The snapshot revert between calls is included in the figures.
+func allocsPerCall(module modules.Module, runParams PrecompileRunparams, stateDB contract.StateDB, snapshot int) (float64, float64, int) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	allocs := testing.AllocsPerRun(allocRuns, func() {
+		stateDB.RevertToSnapshot(snapshot)
+		snapshot = stateDB.Snapshot()
+		_, _, _ = module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
+	})
+	runtime.ReadMemStats(&after)
+	// AllocsPerRun makes an additional warm-up call before the measured runs.
+	bytes := float64(after.TotalAlloc-before.TotalAlloc) / (allocRuns + 1)
+	return allocs, bytes, snapshot
+}
 
-	elapsed := uint64(time.Since(start))
-	if elapsed < 1 {
-		elapsed = 1
+// mgasPerSecond returns the throughput of [n] runs each using [gasPerOp] over [elapsed].
+func mgasPerSecond(gasPerOp uint64, n int, elapsed time.Duration) float64 {
+	nanos := uint64(elapsed)
+	if nanos < 1 {
+		nanos = 1
 	}
-	gasUsed := runParams.SuppliedGas * uint64(b.N)
-	b.ReportMetric(float64(runParams.SuppliedGas), "gas/op")
+	gasUsed := gasPerOp * uint64(n)
 	// Keep it as uint64, multiply 100 to get two digit float later
-	mgasps := (100 * 1000 * gasUsed) / elapsed
-	b.ReportMetric(float64(mgasps)/100, "mgas/s")
+	mgasps := (100 * 1000 * gasUsed) / nanos
+	return float64(mgasps) / 100
+}
 
-	// Execute the test one final time to ensure that if our RevertToSnapshot logic breaks such that each run is actually failing or resulting in unexpected behavior
-	// the benchmark should catch the error here.
-	stateDB.RevertToSnapshot(snapshot)
-	ret, remainingGas, err = module.Contract.Run(runParams.AccessibleState, runParams.Caller, runParams.ContractAddress, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
-	if len(test.ExpectedErr) != 0 {
-		require.ErrorContains(b, err, test.ExpectedErr)
-	} else {
-		require.NoError(b, err)
+// concurrentResult holds the outcome of a single call made by RunConcurrent.
+type concurrentResult struct {
+	ret          []byte
+	remainingGas uint64
+	err          error
+	panicked     any
+}
+
+// RunConcurrent runs the read-only [test] against [module] from [parallelism] goroutines at once, each
+// with an independent state from [newStateDB], and asserts that no call panics and that every call
+// returns the expected, identical results. Run it with -race to detect shared mutable state in the
+// precompile. Tests that are not read-only legitimately mutate state and are rejected.
+func RunConcurrent(t *testing.T, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, test PrecompileTest, parallelism int) {
+	t.Helper()
+	require.True(t, test.ReadOnly, "RunConcurrent only supports read-only tests")
+	require.Positive(t, parallelism)
+
+	// Setup uses t, so it must happen on the test goroutine.
+	runParams := make([]PrecompileRunparams, parallelism)
+	for i := range runParams {
+		runParams[i] = test.setup(t, module, newStateDB(t))
+		require.NoError(t, runParams[i].inputErr)
 	}
-	require.Equal(b, uint64(0), remainingGas)
-	require.Equal(b, test.ExpectedRes, ret)
 
-	if test.AfterHook != nil {
-		test.AfterHook(b, state)
+	results := make([]concurrentResult, parallelism)
+	var wg sync.WaitGroup
+	wg.Add(parallelism)
+	for i := range runParams {
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				results[i].panicked = recover()
+			}()
+
+			params := runParams[i]
+			results[i].ret, results[i].remainingGas, results[i].err = module.Contract.Run(params.AccessibleState, params.Caller, params.ContractAddress, params.Input, params.SuppliedGas, params.ReadOnly)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		require.Nil(t, result.panicked, "call %d panicked", i)
+		checkErr(t, result.err, test.ExpectedErr, test.ExpectedErrIs, "call %d", i)
+		test.checkGas(t, runParams[i].SuppliedGas, result.remainingGas)
+		require.Equal(t, test.ExpectedRes, result.ret, "call %d", i)
+		require.Equal(t, results[0], result, "call %d differs from call 0", i)
 	}
 }
 
-func RunPrecompileTests(t *testing.T, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, contractTests map[string]PrecompileTest) {
+// RunPrecompileTests runs each of [contractTests] as a subtest against a state created by [newStateDB].
+// If [parallel] is true, the subtests are run in parallel with each other, so it must only be set
+// when the tests do not depend on running in order or share state outside of their StateDB.
+func RunPrecompileTests(t *testing.T, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, contractTests map[string]PrecompileTest, parallel bool) {
 	t.Helper()
 
 	for name, test := range contractTests {
+		test := test
 		t.Run(name, func(t *testing.T) {
+			if parallel {
+				t.Parallel()
+			}
 			test.Run(t, module, newStateDB(t))
 		})
 	}
@@ -0,0 +1,340 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const counterGasCost = 50
+
+// Operations of counterPrecompile, selected by the first byte of the input.
+const (
+	// opBalance returns the balance of the precompile's address.
+	opBalance byte = iota
+	// opIncrement increments the counter stored by the precompile, emits a log with the
+	// caller as topic and the new count as data, and returns the new count.
+	opIncrement
+	// opBlock blocks until the precompile is released.
+	opBlock
+	// opPanic panics.
+	opPanic
+	// opOtherEnabled returns 1 if the precompile at otherAddress is enabled at the block timestamp, and 0 otherwise.
+	opOtherEnabled
+)
+
+var (
+	counterKey       = common.Hash{}
+	incrementedTopic = common.BytesToHash([]byte("incremented"))
+	otherAddress     = common.HexToAddress("0x0300000000000000000000000000000000000ffd")
+	counterCaller    = common.HexToAddress("0x0000000000000000000000000000000000000001")
+)
+
+// counterPrecompile is a minimal precompile shared by the tests of the precompile test harness.
+type counterPrecompile struct {
+	release chan struct{}
+}
+
+func (p *counterPrecompile) Run(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+	remainingGas, err := contract.DeductGas(suppliedGas, counterGasCost)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(input) != 1 {
+		return nil, remainingGas, fmt.Errorf("%w: %d", contract.ErrInvalidInputLength, len(input))
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	switch input[0] {
+	case opBalance:
+		return common.BigToHash(stateDB.GetBalance(addr)).Bytes(), remainingGas, nil
+	case opIncrement:
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+		count := new(big.Int).Add(stateDB.GetState(addr, counterKey).Big(), common.Big1)
+		stateDB.SetState(addr, counterKey, common.BigToHash(count))
+		ret := common.BigToHash(count).Bytes()
+		stateDB.AddLog(addr, []common.Hash{incrementedTopic, common.BytesToHash(caller.Bytes())}, ret, accessibleState.GetBlockContext().Number().Uint64())
+		return ret, remainingGas, nil
+	case opBlock:
+		<-p.release
+		return nil, remainingGas, nil
+	case opPanic:
+		panic("counter overflow")
+	case opOtherEnabled:
+		if accessibleState.GetChainConfig().IsPrecompileEnabled(otherAddress, accessibleState.GetBlockContext().Timestamp()) {
+			return countHash(1), remainingGas, nil
+		}
+		return countHash(0), remainingGas, nil
+	default:
+		return nil, remainingGas, fmt.Errorf("unknown operation %d", input[0])
+	}
+}
+
+// newCounterModule returns a module for a new counterPrecompile and the channel that releases its blocked calls.
+func newCounterModule() (modules.Module, chan struct{}) {
+	release := make(chan struct{})
+	return modules.Module{
+		ConfigKey: "counterConfig",
+		Address:   common.HexToAddress("0x0300000000000000000000000000000000000ffc"),
+		Contract:  &counterPrecompile{release: release},
+	}, release
+}
+
+// countHash returns the counter value [count] as returned by counterPrecompile.
+func countHash(count int64) []byte {
+	return common.BigToHash(big.NewInt(count)).Bytes()
+}
+
+func TestRunPrecompileTests(t *testing.T) {
+	module, _ := newCounterModule()
+	RunPrecompileTests(t, module, state.NewTestStateDB, map[string]PrecompileTest{
+		"increment": {
+			Caller:      counterCaller,
+			Input:       []byte{opIncrement},
+			SuppliedGas: counterGasCost,
+			ExpectedRes: countHash(1),
+			ExpectedLogs: []Log{
+				{Topics: []common.Hash{incrementedTopic, common.BytesToHash(counterCaller.Bytes())}, Data: countHash(1)},
+			},
+			ExpectedRefund: new(uint64),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, common.BigToHash(common.Big1), state.GetState(module.Address, counterKey))
+			},
+		},
+		"increment in read only mode": {
+			Input:         []byte{opIncrement},
+			SuppliedGas:   counterGasCost,
+			ReadOnly:      true,
+			ExpectedErrIs: vmerrs.ErrWriteProtection,
+		},
+		"increment with insufficient gas": {
+			Input:         []byte{opIncrement},
+			SuppliedGas:   counterGasCost - 1,
+			ExpectedErrIs: vmerrs.ErrOutOfGas,
+		},
+		"increment with leftover gas": {
+			Input:           []byte{opIncrement},
+			SuppliedGas:     2 * counterGasCost,
+			ExpectedRes:     countHash(1),
+			ExpectedGasUsed: counterGasCost,
+		},
+		"increment at another address": {
+			ContractAddress: &otherAddress,
+			Input:           []byte{opIncrement},
+			SuppliedGas:     counterGasCost,
+			ExpectedRes:     countHash(1),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, common.BigToHash(common.Big1), state.GetState(otherAddress, counterKey))
+				require.Equal(t, common.Hash{}, state.GetState(module.Address, counterKey))
+			},
+		},
+		"increment in steps by several callers": {
+			Caller:      counterCaller,
+			Input:       []byte{opIncrement},
+			SuppliedGas: counterGasCost,
+			ExpectedRes: countHash(1),
+			Steps: []PrecompileStep{
+				{Caller: &otherAddress, Input: []byte{opIncrement}, SuppliedGas: counterGasCost, ExpectedRes: countHash(2)},
+				{Input: []byte{opIncrement}, SuppliedGas: counterGasCost, ReadOnly: true, ExpectedErrIs: vmerrs.ErrWriteProtection},
+				{Input: []byte{opIncrement}, SuppliedGas: counterGasCost - 1, ExpectedErrIs: vmerrs.ErrOutOfGas},
+			},
+			ExpectedLogs: []Log{
+				{Topics: []common.Hash{incrementedTopic, common.BytesToHash(counterCaller.Bytes())}, Data: countHash(1)},
+				{Topics: []common.Hash{incrementedTopic, common.BytesToHash(otherAddress.Bytes())}, Data: countHash(2)},
+			},
+		},
+		"capture and compare count": {
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetState(module.Address, counterKey, common.BigToHash(big.NewInt(41)))
+			},
+			CaptureHook: func(t testing.TB, state contract.StateDB) any {
+				return state.GetState(module.Address, counterKey).Big()
+			},
+			CompareHook: func(t testing.TB, state contract.StateDB, captured any) {
+				expected := new(big.Int).Add(captured.(*big.Int), common.Big1)
+				require.Equal(t, expected, state.GetState(module.Address, counterKey).Big())
+			},
+			Input:       []byte{opIncrement},
+			SuppliedGas: counterGasCost,
+			ExpectedRes: countHash(42),
+		},
+		"balance with value": {
+			Input:       []byte{opBalance},
+			SuppliedGas: counterGasCost,
+			Value:       common.Big2,
+			ExpectedRes: countHash(2),
+		},
+		"balance with value in steps": {
+			Input:       []byte{opBalance},
+			SuppliedGas: counterGasCost,
+			Value:       common.Big1,
+			ExpectedRes: countHash(1),
+			Steps: []PrecompileStep{
+				{Input: []byte{opBalance}, SuppliedGas: counterGasCost, ExpectedRes: countHash(1)},
+				{Input: []byte{opBalance}, SuppliedGas: counterGasCost, Value: common.Big2, ExpectedRes: countHash(3)},
+			},
+		},
+		"balance with value at another address": {
+			ContractAddress: &otherAddress,
+			Input:           []byte{opBalance},
+			SuppliedGas:     counterGasCost,
+			Value:           common.Big3,
+			ExpectedRes:     countHash(3),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Zero(t, state.GetBalance(module.Address).Sign())
+			},
+		},
+		"other precompile enabled": {
+			Input:       []byte{opOtherEnabled},
+			SuppliedGas: counterGasCost,
+			ExpectedRes: countHash(1),
+		},
+		"other precompile not enabled": {
+			ConfigureChainConfig: func(c *precompileconfig.MockChainConfig) {
+				c.EXPECT().IsPrecompileEnabled(otherAddress, uint64(100)).Return(false)
+			},
+			SetupBlockContext: func(c *contract.MockBlockContext) {
+				c.EXPECT().Timestamp().Return(uint64(100))
+			},
+			Input:       []byte{opOtherEnabled},
+			SuppliedGas: counterGasCost,
+			ExpectedRes: countHash(0),
+		},
+		"invalid input": {
+			Input:         []byte{opBalance, opBalance},
+			SuppliedGas:   counterGasCost,
+			ExpectedErrIs: contract.ErrInvalidInputLength,
+		},
+	}, true)
+}
+
+func TestRunResult(t *testing.T) {
+	module, _ := newCounterModule()
+	test := PrecompileTest{
+		Input:           []byte{opIncrement},
+		SuppliedGas:     2 * counterGasCost,
+		ExpectedRes:     countHash(1),
+		ExpectedGasUsed: counterGasCost,
+	}
+	result := test.RunResult(t, module, state.NewTestStateDB(t))
+	require.Equal(t, PrecompileResult{Ret: countHash(1), GasUsed: counterGasCost}, result)
+
+	test = PrecompileTest{
+		Input:         []byte{opIncrement},
+		SuppliedGas:   counterGasCost,
+		ReadOnly:      true,
+		ExpectedErrIs: vmerrs.ErrWriteProtection,
+	}
+	result = test.RunResult(t, module, state.NewTestStateDB(t))
+	require.ErrorIs(t, result.Err, vmerrs.ErrWriteProtection)
+	require.Equal(t, uint64(counterGasCost), result.GasUsed)
+}
+
+func TestMeasure(t *testing.T) {
+	module, _ := newCounterModule()
+	// Measure does not assert against the expectations of the test.
+	test := PrecompileTest{
+		Input:       []byte{opIncrement},
+		SuppliedGas: counterGasCost + 1,
+		ExpectedErr: "ignored",
+	}
+	gasUsed, ret, err := test.Measure(t, module, state.NewTestStateDB(t))
+	require.NoError(t, err)
+	require.Equal(t, uint64(counterGasCost), gasUsed)
+	require.Equal(t, countHash(1), ret)
+}
+
+func TestNewFundedStateDB(t *testing.T) {
+	module, _ := newCounterModule()
+	newStateDB := NewFundedStateDB(state.NewTestStateDB, map[common.Address]*big.Int{
+		module.Address: common.Big2,
+		otherAddress:   common.Big3,
+	})
+	RunPrecompileTests(t, module, newStateDB, map[string]PrecompileTest{
+		"balance of funded address": {
+			Input:       []byte{opBalance},
+			SuppliedGas: counterGasCost,
+			ExpectedRes: countHash(2),
+		},
+		"balance of funded address with value": {
+			Input:       []byte{opBalance},
+			SuppliedGas: counterGasCost,
+			Value:       common.Big1,
+			ExpectedRes: countHash(3),
+		},
+		"balance of address funded in before hook": {
+			ContractAddress: &otherAddress,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				FundAccounts(state, map[common.Address]*big.Int{otherAddress: common.Big1})
+			},
+			Input:       []byte{opBalance},
+			SuppliedGas: counterGasCost,
+			ExpectedRes: countHash(4),
+		},
+	}, false)
+}
+
+// fatalRecorder is a testing.TB that records calls to Fatalf instead of stopping the test.
+type fatalRecorder struct {
+	testing.TB
+	fatal string
+}
+
+func (r *fatalRecorder) Helper() {}
+
+func (r *fatalRecorder) Fatalf(format string, args ...any) {
+	r.fatal = fmt.Sprintf(format, args...)
+}
+
+func TestRunWithTimeout(t *testing.T) {
+	module, release := newCounterModule()
+	defer close(release)
+	test := PrecompileTest{
+		Input:       []byte{opBlock},
+		SuppliedGas: counterGasCost,
+	}
+	runParams := test.setup(t, module, state.NewTestStateDB(t))
+
+	recorder := &fatalRecorder{TB: t}
+	_, _, _ = runWithTimeout(recorder, 10*time.Millisecond, module, runParams, runParams.Input, runParams.SuppliedGas, false)
+	require.Equal(t, "precompile counterConfig did not return within 10ms", recorder.fatal)
+
+	// A panic fails the test with the stack of the precompile.
+	recorder = &fatalRecorder{TB: t}
+	_, _, _ = runWithTimeout(recorder, 0, module, runParams, []byte{opPanic}, counterGasCost, false)
+	require.Contains(t, recorder.fatal, "precompile counterConfig panicked: counter overflow")
+	require.Contains(t, recorder.fatal, "(*counterPrecompile).Run")
+
+	recorder = &fatalRecorder{TB: t}
+	ret, remainingGas, err := runWithTimeout(recorder, 0, module, runParams, []byte{opBalance}, counterGasCost, false)
+	require.Empty(t, recorder.fatal)
+	require.NoError(t, err)
+	require.Zero(t, remainingGas)
+	require.Equal(t, countHash(0), ret)
+}
+
+// BenchmarkCompareBench compares the module against itself, so the reported delta is noise.
+func BenchmarkCompareBench(b *testing.B) {
+	moduleA, _ := newCounterModule()
+	moduleB, _ := newCounterModule()
+	test := PrecompileTest{
+		Input:       []byte{opIncrement},
+		SuppliedGas: counterGasCost,
+		ExpectedRes: countHash(1),
+	}
+	test.CompareBench(b, moduleA, moduleB, state.NewTestStateDB)
+}
@@ -0,0 +1,104 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Caller = (*ReentrantAccessibleState)(nil)
+
+// Caller is implemented by an AccessibleState that lets a precompile call back into the precompile
+// that is executing. contract.AccessibleState does not expose calls, so a precompile under test
+// reaches Call by asserting its AccessibleState to a Caller.
+type Caller interface {
+	// Call calls the precompile with [input] from [caller] one call frame deeper than the current call.
+	Call(caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error)
+}
+
+// ReentrantAccessibleState wraps an AccessibleState so that the precompile being run can re-enter
+// itself, as if it had called into the EVM and the EVM had called the precompile again within the
+// same execution. Calls deeper than the maximum depth fail with vmerrs.ErrDepth, as in the EVM.
+type ReentrantAccessibleState struct {
+	contract.AccessibleState
+	precompile contract.StatefulPrecompiledContract
+	address    common.Address
+	maxDepth   int
+	// depth is the number of calls to the precompile in progress, and maxDepthReached the largest
+	// depth reached since the state was created.
+	depth           int
+	maxDepthReached int
+}
+
+// NewReentrantAccessibleState returns a ReentrantAccessibleState wrapping [accessibleState] whose Call
+// re-enters [precompile] at [address], allowing up to [maxDepth] calls to be in progress at once.
+func NewReentrantAccessibleState(accessibleState contract.AccessibleState, precompile contract.StatefulPrecompiledContract, address common.Address, maxDepth int) *ReentrantAccessibleState {
+	return &ReentrantAccessibleState{
+		AccessibleState: accessibleState,
+		precompile:      precompile,
+		address:         address,
+		maxDepth:        maxDepth,
+	}
+}
+
+func (s *ReentrantAccessibleState) Call(caller common.Address, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+	if s.depth >= s.maxDepth {
+		return nil, suppliedGas, vmerrs.ErrDepth
+	}
+	s.depth++
+	if s.depth > s.maxDepthReached {
+		s.maxDepthReached = s.depth
+	}
+	defer func() { s.depth-- }()
+
+	return s.precompile.Run(s, caller, s.address, input, suppliedGas, readOnly)
+}
+
+// Depth returns the number of calls to the precompile in progress.
+func (s *ReentrantAccessibleState) Depth() int {
+	return s.depth
+}
+
+// MaxDepthReached returns the largest number of calls to the precompile that were in progress at once.
+func (s *ReentrantAccessibleState) MaxDepthReached() int {
+	return s.maxDepthReached
+}
+
+// RunReentrant calls [module] with the Input of [test] through a ReentrantAccessibleState allowing up to
+// [maxDepth] nested calls, so the precompile can re-enter itself through Caller, and asserts the result
+// against the expected result, error and gas of [test]. The Value of [test] is credited to the contract
+// address once, before the outermost call, and reentrant calls carry no value. AfterHook is called after
+// the call returns. Steps are rejected, and expected logs and the other checks of Run are ignored.
+// Returns the result of the outermost call and the largest call depth that was reached.
+func RunReentrant(t *testing.T, module modules.Module, state contract.StateDB, test PrecompileTest, maxDepth int) (PrecompileResult, int) {
+	t.Helper()
+	require.Positive(t, maxDepth)
+	require.Empty(t, test.Steps, "RunReentrant does not support Steps")
+
+	runParams := test.setup(t, module, state)
+	require.NoError(t, runParams.inputErr)
+	reentrantState := NewReentrantAccessibleState(runParams.AccessibleState, module.Contract, runParams.ContractAddress, maxDepth)
+
+	ret, remainingGas, err := reentrantState.Call(runParams.Caller, runParams.Input, runParams.SuppliedGas, runParams.ReadOnly)
+	require.Zero(t, reentrantState.Depth(), "calls still in progress after the precompile returned")
+	checkErr(t, err, test.ExpectedErr, test.ExpectedErrIs)
+	test.checkGas(t, runParams.SuppliedGas, remainingGas)
+	require.Equal(t, test.ExpectedRes, ret)
+	if test.AfterHook != nil {
+		test.AfterHook(t, state)
+	}
+
+	result := PrecompileResult{
+		Ret:     ret,
+		GasUsed: runParams.SuppliedGas - remainingGas,
+		Err:     err,
+	}
+	return result, reentrantState.MaxDepthReached()
+}
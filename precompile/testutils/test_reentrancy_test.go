@@ -0,0 +1,137 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const reentrantCallCost = 10
+
+var errReentrantCall = errors.New("reentrant call")
+
+// countdownPrecompile re-enters itself [input[0]] times and returns the depth of each call, outermost last.
+// If [guard] is true, it rejects reentrant calls with an in-memory lock held for the duration of a call.
+type countdownPrecompile struct {
+	guard  bool
+	locked bool
+}
+
+func (p *countdownPrecompile) Run(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+	remainingGas, err := contract.DeductGas(suppliedGas, reentrantCallCost)
+	if err != nil {
+		return nil, 0, err
+	}
+	if p.guard {
+		if p.locked {
+			return nil, remainingGas, errReentrantCall
+		}
+		p.locked = true
+		defer func() { p.locked = false }()
+	}
+	if len(input) == 0 || input[0] == 0 {
+		return []byte{0}, remainingGas, nil
+	}
+
+	ret, remainingGas, err := accessibleState.(Caller).Call(addr, []byte{input[0] - 1}, remainingGas, readOnly)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return append(ret, input[0]), remainingGas, nil
+}
+
+func newCountdownModule(guard bool) modules.Module {
+	return modules.Module{
+		ConfigKey: "countdownConfig",
+		Address:   common.HexToAddress("0x0300000000000000000000000000000000000fff"),
+		Contract:  &countdownPrecompile{guard: guard},
+	}
+}
+
+func TestRunReentrant(t *testing.T) {
+	tests := map[string]struct {
+		guard            bool
+		input            byte
+		maxDepth         int
+		test             PrecompileTest
+		expectedMaxDepth int
+	}{
+		"reentrant calls within max depth": {
+			input:    3,
+			maxDepth: 4,
+			test: PrecompileTest{
+				SuppliedGas:     100,
+				ExpectedRes:     []byte{0, 1, 2, 3},
+				ExpectedGasUsed: 4 * reentrantCallCost,
+			},
+			expectedMaxDepth: 4,
+		},
+		"reentrant calls exceeding max depth": {
+			input:    3,
+			maxDepth: 3,
+			test: PrecompileTest{
+				SuppliedGas:     100,
+				ExpectedErrIs:   vmerrs.ErrDepth,
+				ExpectedGasUsed: 3 * reentrantCallCost,
+			},
+			expectedMaxDepth: 3,
+		},
+		"reentrant call rejected by guard": {
+			guard:    true,
+			input:    1,
+			maxDepth: 4,
+			test: PrecompileTest{
+				SuppliedGas:     100,
+				ExpectedErrIs:   errReentrantCall,
+				ExpectedGasUsed: 2 * reentrantCallCost,
+			},
+			expectedMaxDepth: 2,
+		},
+		"guard released after call": {
+			guard:    true,
+			input:    0,
+			maxDepth: 4,
+			test: PrecompileTest{
+				SuppliedGas:     100,
+				ExpectedRes:     []byte{0},
+				ExpectedGasUsed: reentrantCallCost,
+			},
+			expectedMaxDepth: 1,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			module := newCountdownModule(tt.guard)
+			tt.test.Input = []byte{tt.input}
+			_, maxDepth := RunReentrant(t, module, state.NewTestStateDB(t), tt.test, tt.maxDepth)
+			require.Equal(t, tt.expectedMaxDepth, maxDepth)
+
+			// The in-memory state of the precompile must not leak into the next execution.
+			_, maxDepth = RunReentrant(t, module, state.NewTestStateDB(t), tt.test, tt.maxDepth)
+			require.Equal(t, tt.expectedMaxDepth, maxDepth)
+		})
+	}
+}
+
+func TestRunReentrantValue(t *testing.T) {
+	module, _ := newCounterModule()
+	test := PrecompileTest{
+		Input:       []byte{opBalance},
+		SuppliedGas: counterGasCost,
+		Value:       common.Big2,
+		ExpectedRes: countHash(2),
+	}
+	result, maxDepth := RunReentrant(t, module, state.NewTestStateDB(t), test, 1)
+	require.Equal(t, PrecompileResult{Ret: countHash(2), GasUsed: counterGasCost}, result)
+	require.Equal(t, 1, maxDepth)
+}
@@ -0,0 +1,106 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Log is the content of a log emitted by a precompile.
+// The block number is intentionally omitted, so tests do not need to hardcode it.
+type Log struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+}
+
+// logRecorderStateDB wraps a StateDB and records every log added to it.
+type logRecorderStateDB struct {
+	contract.StateDB
+	logs []Log
+}
+
+func (s *logRecorderStateDB) AddLog(addr common.Address, topics []common.Hash, data []byte, blockNumber uint64) {
+	s.logs = append(s.logs, Log{Address: addr, Topics: topics, Data: data})
+	s.StateDB.AddLog(addr, topics, data, blockNumber)
+}
+
+// StateAccess is a read or write of a storage slot performed by a precompile.
+type StateAccess = contract.StateAccess
+
+// accessRecorderStateDB wraps a StateDB and records every storage slot read or written, in order.
+type accessRecorderStateDB struct {
+	contract.StateDB
+	accesses []StateAccess
+}
+
+func (s *accessRecorderStateDB) GetState(addr common.Address, key common.Hash) common.Hash {
+	s.accesses = append(s.accesses, StateAccess{Address: addr, Key: key})
+	return s.StateDB.GetState(addr, key)
+}
+
+func (s *accessRecorderStateDB) SetState(addr common.Address, key common.Hash, value common.Hash) {
+	s.accesses = append(s.accesses, StateAccess{Address: addr, Key: key, Write: true})
+	s.StateDB.SetState(addr, key, value)
+}
+
+// writeRecorderStateDB wraps a StateDB and records a description of every
+// operation that modifies state, so read only calls can be verified to be side-effect free.
+type writeRecorderStateDB struct {
+	contract.StateDB
+	writes []string
+}
+
+func (s *writeRecorderStateDB) SetState(addr common.Address, key common.Hash, value common.Hash) {
+	s.writes = append(s.writes, fmt.Sprintf("SetState(%s, %s, %s)", addr, key, value))
+	s.StateDB.SetState(addr, key, value)
+}
+
+func (s *writeRecorderStateDB) SetNonce(addr common.Address, nonce uint64) {
+	s.writes = append(s.writes, fmt.Sprintf("SetNonce(%s, %d)", addr, nonce))
+	s.StateDB.SetNonce(addr, nonce)
+}
+
+func (s *writeRecorderStateDB) AddBalance(addr common.Address, amount *big.Int) {
+	s.writes = append(s.writes, fmt.Sprintf("AddBalance(%s, %s)", addr, amount))
+	s.StateDB.AddBalance(addr, amount)
+}
+
+func (s *writeRecorderStateDB) CreateAccount(addr common.Address) {
+	s.writes = append(s.writes, fmt.Sprintf("CreateAccount(%s)", addr))
+	s.StateDB.CreateAccount(addr)
+}
+
+func (s *writeRecorderStateDB) AddLog(addr common.Address, topics []common.Hash, data []byte, blockNumber uint64) {
+	s.writes = append(s.writes, fmt.Sprintf("AddLog(%s)", addr))
+	s.StateDB.AddLog(addr, topics, data, blockNumber)
+}
+
+func (s *writeRecorderStateDB) Suicide(addr common.Address) bool {
+	s.writes = append(s.writes, fmt.Sprintf("Suicide(%s)", addr))
+	return s.StateDB.Suicide(addr)
+}
+
+// FundAccounts adds the amount in [balances] to the balance of each account in [state].
+// It does not take a testing context, so it can be called from a BeforeHook of both tests and benchmarks.
+func FundAccounts(state contract.StateDB, balances map[common.Address]*big.Int) {
+	for addr, balance := range balances {
+		state.AddBalance(addr, balance)
+	}
+}
+
+// NewFundedStateDB returns a factory for states created by [newStateDB], where each account in [balances] is
+// funded with FundAccounts. The returned function can be used anywhere [newStateDB] is expected.
+func NewFundedStateDB(newStateDB func(t testing.TB) contract.StateDB, balances map[common.Address]*big.Int) func(t testing.TB) contract.StateDB {
+	return func(t testing.TB) contract.StateDB {
+		state := newStateDB(t)
+		FundAccounts(state, balances)
+		return state
+	}
+}
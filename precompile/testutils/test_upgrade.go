@@ -0,0 +1,58 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/stretchr/testify/require"
+)
+
+// UpgradePhase activates Config and then calls the precompile as described by Test.
+type UpgradePhase struct {
+	// Config is activated at the start of the phase. If it is a disable config, the
+	// precompile's state is removed as when the chain disables the precompile.
+	Config precompileconfig.Config
+	// Test is run against the state after Config has been activated.
+	// Its own Config is ignored.
+	Test PrecompileTest
+}
+
+// RunUpgradeTest activates the config of each phase in order against a single state from [newStateDB],
+// mirroring the precompile activations applied by the chain, and runs the test of each phase after its
+// config is activated. The configs must form a valid upgrade sequence for the precompile.
+func RunUpgradeTest(t *testing.T, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, phases []UpgradePhase) {
+	t.Helper()
+
+	configs := make([]precompileconfig.Config, len(phases))
+	for i, phase := range phases {
+		configs[i] = phase.Config
+	}
+	require.NoError(t, precompileconfig.VerifyUpgrades(configs))
+
+	state := newStateDB(t)
+	for i, phase := range phases {
+		test := phase.Test
+		test.Config = nil
+		if phase.Config.IsDisabled() {
+			state.Suicide(module.Address)
+			// Commit the removal so that the state is wiped before the next activation.
+			state.Finalise(true)
+		} else {
+			// Mark the precompile's account as non-empty so it is not cleaned up when the state is finalized.
+			state.SetNonce(module.Address, 1)
+			test.Config = phase.Config
+		}
+
+		if !t.Run(fmt.Sprintf("phase %d", i), func(t *testing.T) {
+			test.Run(t, module, state)
+		}) {
+			return
+		}
+	}
+}
@@ -62,6 +62,14 @@ func BigNumEqual(x, y *big.Int) bool {
 	return x.Cmp(y) == 0
 }
 
+// BigNumCopy returns a copy of [x] that does not share its underlying value, or nil if [x] is nil.
+func BigNumCopy(x *big.Int) *big.Int {
+	if x == nil {
+		return nil
+	}
+	return new(big.Int).Set(x)
+}
+
 // Uint64PtrEqual returns true if x and y pointers are equivalent ie. both nil or both
 // contain the same value.
 func Uint64PtrEqual(x, y *uint64) bool {
@@ -4,8 +4,12 @@
 package warp
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
@@ -13,15 +17,56 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
-	"github.com/ava-labs/subnet-evm/ethdb"
+	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 var _ Backend = &backend{}
 
-const batchSize = ethdb.IdealBatchSize
+const (
+	// DefaultBackendCacheSize is the number of signatures and messages cached by a Backend whose
+	// BackendConfig does not set CacheSize.
+	DefaultBackendCacheSize = 500
+	// clearBatchKeys is the number of keys Clear deletes at once.
+	clearBatchKeys = 1024
+	// subscriptionBufferSize is the number of signed messages buffered for each subscription
+	// before further messages are dropped for that subscriber.
+	subscriptionBufferSize = 256
+	// reSignLogInterval is how often ReSignAll logs its progress.
+	reSignLogInterval = 8 * time.Second
+)
+
+var (
+	// timestampPrefix prefixes the db key storing the time a message was added.
+	// Message keys are unprefixed message IDs, so prefixed keys never collide with them.
+	timestampPrefix = []byte("timestamp")
+	// signaturePrefix prefixes the db key storing a persisted message signature, which is
+	// saved together with the public key it was produced with.
+	signaturePrefix = []byte("signature")
+	// healthCheckPayload is the payload of the message signed by HealthCheck. The message is
+	// the same on every check and is never stored or cached.
+	healthCheckPayload = []byte("warp backend health check")
+)
+
+var (
+	// ErrMessageTooLarge is returned by AddMessage if the message exceeds the backend's maximum message size.
+	ErrMessageTooLarge = errors.New("warp message too large")
+	// ErrInvalidMessage is returned by AddMessage if the message does not parse.
+	ErrInvalidMessage = errors.New("invalid warp message")
+
+	errMessageIDMismatch    = errors.New("warp message ID mismatch")
+	errBackendClosed        = errors.New("warp backend closed")
+	errHealthCheckSignature = errors.New("health check signature does not verify with the backend's public key")
+)
+
+// SignedMessage is a warp message added to the backend together with the backend's signature of it.
+type SignedMessage struct {
+	Message   *avalancheWarp.UnsignedMessage
+	Signature [bls.SignatureLen]byte
+}
 
 type BlockClient interface {
 	GetBlock(ctx context.Context, blockID ids.ID) (snowman.Block, error)
@@ -30,106 +75,674 @@ type BlockClient interface {
 // Backend tracks signature-eligible warp messages and provides an interface to fetch them.
 // The backend is also used to query for warp message signatures by the signature request handler.
 type Backend interface {
-	// AddMessage signs [unsignedMessage] and adds it to the warp backend database
+	// AddMessage signs [unsignedMessage] and adds it to the warp backend database.
+	// Adding a message that is already tracked is a no-op. Returns ErrMessageTooLarge or
+	// ErrInvalidMessage, without storing or signing the message, if it is rejected.
 	AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage) error
 
 	// GetMessageSignature returns the signature of the requested message hash.
-	GetMessageSignature(messageID ids.ID) ([bls.SignatureLen]byte, error)
+	// Returns ctx.Err() if [ctx] is done before the message is signed.
+	GetMessageSignature(ctx context.Context, messageID ids.ID) ([bls.SignatureLen]byte, error)
+
+	// GetMessageSignatures returns the signatures of the requested message hashes, looking each one up as
+	// GetMessageSignature does, but signing the messages without a stored signature only once all of them
+	// have been looked up. The signature and error for each message are returned at the same index as
+	// the message, so a missing message does not prevent the other signatures from being returned: its
+	// signature is left zero and its error wraps database.ErrNotFound. If [ctx] is done, the messages not
+	// yet looked up are given ctx.Err().
+	GetMessageSignatures(ctx context.Context, messageIDs []ids.ID) ([][bls.SignatureLen]byte, []error)
 
 	// GetBlockSignature returns the signature of the requested message hash.
-	GetBlockSignature(blockID ids.ID) ([bls.SignatureLen]byte, error)
+	// Returns ctx.Err() if [ctx] is done before the block is signed.
+	GetBlockSignature(ctx context.Context, blockID ids.ID) ([bls.SignatureLen]byte, error)
 
 	// GetMessage retrieves the [unsignedMessage] from the warp backend database if available
 	GetMessage(messageHash ids.ID) (*avalancheWarp.UnsignedMessage, error)
 
+	// HasMessage returns whether [messageID] is tracked by the warp backend without signing it.
+	HasMessage(ctx context.Context, messageID ids.ID) (bool, error)
+
+	// GetMessageIDs returns the IDs of all messages tracked in the warp backend database.
+	GetMessageIDs(ctx context.Context) ([]ids.ID, error)
+
+	// Prune removes messages added before [before] from the warp backend database and
+	// returns the number of messages removed. Messages whose signatures are cached are
+	// still being requested, so they are kept. Messages stored before timestamps were
+	// tracked are kept and timestamped with the current time, so they are pruned once
+	// that time is before [before].
+	Prune(ctx context.Context, before time.Time) (int, error)
+
+	// DeleteMessage removes [messageID] from the warp backend database along with its
+	// cached signature. Deleting an unknown message is a no-op.
+	DeleteMessage(ctx context.Context, messageID ids.ID) error
+
+	// ReSignAll signs every message in the warp backend database with the current key and caches
+	// the signatures, replacing any made with a previous key. It is intended to be called after a
+	// BLS key rotation so signature requests do not have to wait for messages to be re-signed lazily.
+	ReSignAll(ctx context.Context) error
+
+	// SubscribeNewSignatures returns a channel that receives each message signed by AddMessage after
+	// the subscription is made, so callers can be notified of new messages instead of polling.
+	// The channel is closed once [ctx] is done or the backend is closed. Messages are dropped rather
+	// than block signing if the subscriber falls too far behind.
+	SubscribeNewSignatures(ctx context.Context) (<-chan SignedMessage, error)
+
+	// HealthCheck returns an error unless the warp backend database is reachable and the signer
+	// can sign. The signer is checked by signing a fixed message, which is not stored.
+	HealthCheck(ctx context.Context) error
+
+	// CurrentPublicKey returns the public key the backend signs with, or nil if it is unknown.
+	// Callers can compare it with a previously observed key to detect a BLS key rotation.
+	CurrentPublicKey() *bls.PublicKey
+
 	// Clear clears the entire db
 	Clear() error
+
+	// Close stops accepting asynchronous signing work and waits for queued signatures to finish.
+	Close()
 }
 
 // backend implements Backend, keeps track of warp messages, and generates message signatures.
 type backend struct {
 	networkID             uint32
 	sourceChainID         ids.ID
-	db                    database.Database
+	db                    Storage
 	warpSigner            avalancheWarp.Signer
 	blockClient           BlockClient
 	messageSignatureCache *cache.LRU[ids.ID, [bls.SignatureLen]byte]
 	blockSignatureCache   *cache.LRU[ids.ID, [bls.SignatureLen]byte]
 	messageCache          *cache.LRU[ids.ID, *avalancheWarp.UnsignedMessage]
+	stats                 *backendStats
+	clock                 mockable.Clock
+	// publicKey is the public key of [warpSigner], and publicKeyBytes its compressed form.
+	publicKey      *bls.PublicKey
+	publicKeyBytes []byte
+	// persistSignatures is true if message signatures are saved in [db] tagged with [publicKeyBytes].
+	persistSignatures bool
+	// maxMessageSize is the maximum size in bytes of a message accepted by AddMessage, or 0 if unlimited.
+	maxMessageSize int
+	// missCache maps the IDs of messages recently found missing from [db] to the time the miss expires,
+	// so repeated requests for a message that has not been added yet do not each read [db].
+	// It is nil if misses are not cached.
+	missCache    *cache.LRU[ids.ID, time.Time]
+	missCacheTTL time.Duration
+	// signAttempts is the number of times signing a message added by AddMessage is attempted before
+	// giving up, waiting [signRetryDelay] between attempts.
+	signAttempts   int
+	signRetryDelay time.Duration
+
+	// signingQueue is nil if messages are signed synchronously in AddMessage.
+	signingQueue chan *avalancheWarp.UnsignedMessage
+	signingWg    sync.WaitGroup
+	// closeLock guards [closed] and sends on [signingQueue].
+	closeLock sync.RWMutex
+	closed    bool
+	// shutdown is closed by Close to stop the goroutines waiting on SubscribeNewSignatures subscriptions.
+	shutdown chan struct{}
+	// pendingLock guards [pending], which maps queued messages to a channel closed once signed.
+	pendingLock sync.Mutex
+	pending     map[ids.ID]chan struct{}
+
+	// subscribersLock guards [subscribers], the channels of the SubscribeNewSignatures subscriptions.
+	// [subscribers] is nil once the backend is closed.
+	subscribersLock sync.Mutex
+	subscribers     map[chan SignedMessage]struct{}
 }
 
-// NewBackend creates a new Backend, and initializes the signature cache and message tracking database.
-func NewBackend(networkID uint32, sourceChainID ids.ID, warpSigner avalancheWarp.Signer, blockClient BlockClient, db database.Database, cacheSize int) Backend {
-	return &backend{
-		networkID:             networkID,
-		sourceChainID:         sourceChainID,
+// BackendConfig configures a Backend. The zero value of each optional field selects its default.
+type BackendConfig struct {
+	NetworkID     uint32
+	SourceChainID ids.ID
+	Signer        avalancheWarp.Signer
+	BlockClient   BlockClient
+
+	// CacheSize is the number of signatures and messages cached, or DefaultBackendCacheSize if not positive.
+	CacheSize int
+	// MetricsRegistry is the registry backend metrics are registered in, or the default registry if nil.
+	MetricsRegistry metrics.Registry
+	// SigningConcurrency is the number of workers AddMessage signs messages with in the background.
+	// Messages are signed synchronously if it is not positive.
+	SigningConcurrency int
+	// PublicKey is the public key of [Signer]. If it is non-nil and [PersistSignatures] is true, message
+	// signatures are also persisted in the database alongside that key so they survive restarts. Persisted
+	// signatures made with a different key, for example before the node's BLS key was rotated, are discarded
+	// and the message is signed again.
+	PublicKey         *bls.PublicKey
+	PersistSignatures bool
+	// MaxMessageSize is the maximum size in bytes of a message accepted by AddMessage, or unlimited if not positive.
+	MaxMessageSize int
+	// MissCacheTTL is how long a message found missing from the database is reported missing without reading
+	// the database again, or until it is added. Misses are not cached if it is not positive.
+	MissCacheTTL time.Duration
+	// SignAttempts is the number of times signing a message added by AddMessage is attempted, waiting
+	// [SignRetryDelay] between attempts, before signing is considered failed. Messages are signed once if
+	// it is not positive.
+	SignAttempts   int
+	SignRetryDelay time.Duration
+}
+
+// NewBackend creates a new Backend as configured by [config], keeping messages and signatures in [db].
+func NewBackend(db database.Database, config BackendConfig) Backend {
+	return NewBackendWithStorage(NewDatabaseStorage(db), config)
+}
+
+// NewBackendWithStorage creates a new Backend as NewBackend does, keeping messages and signatures in [db]
+// instead of a database.Database.
+func NewBackendWithStorage(db Storage, config BackendConfig) Backend {
+	cacheSize := config.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = DefaultBackendCacheSize
+	}
+	b := &backend{
+		networkID:             config.NetworkID,
+		sourceChainID:         config.SourceChainID,
 		db:                    db,
-		warpSigner:            warpSigner,
-		blockClient:           blockClient,
+		warpSigner:            config.Signer,
+		blockClient:           config.BlockClient,
 		messageSignatureCache: &cache.LRU[ids.ID, [bls.SignatureLen]byte]{Size: cacheSize},
 		blockSignatureCache:   &cache.LRU[ids.ID, [bls.SignatureLen]byte]{Size: cacheSize},
 		messageCache:          &cache.LRU[ids.ID, *avalancheWarp.UnsignedMessage]{Size: cacheSize},
+		stats:                 newBackendStats(config.MetricsRegistry),
+		pending:               make(map[ids.ID]chan struct{}),
+		subscribers:           make(map[chan SignedMessage]struct{}),
+		shutdown:              make(chan struct{}),
+		maxMessageSize:        config.MaxMessageSize,
+		signAttempts:          1,
+	}
+	if config.PublicKey != nil {
+		b.publicKey = config.PublicKey
+		b.publicKeyBytes = bls.PublicKeyToBytes(config.PublicKey)
+		b.persistSignatures = config.PersistSignatures
+	}
+	if config.MissCacheTTL > 0 {
+		b.missCache = &cache.LRU[ids.ID, time.Time]{Size: cacheSize}
+		b.missCacheTTL = config.MissCacheTTL
+	}
+	if config.SignAttempts > 1 {
+		b.signAttempts = config.SignAttempts
+		b.signRetryDelay = config.SignRetryDelay
+	}
+	if config.SigningConcurrency > 0 {
+		b.signingQueue = make(chan *avalancheWarp.UnsignedMessage, config.SigningConcurrency)
+		b.signingWg.Add(config.SigningConcurrency)
+		for i := 0; i < config.SigningConcurrency; i++ {
+			go b.signingWorker()
+		}
+	}
+	return b
+}
+
+func (b *backend) Close() {
+	b.closeLock.Lock()
+	defer b.closeLock.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	close(b.shutdown)
+	if b.signingQueue != nil {
+		close(b.signingQueue)
+	}
+	b.signingWg.Wait()
+
+	b.subscribersLock.Lock()
+	for ch := range b.subscribers {
+		close(ch)
+	}
+	b.subscribers = nil
+	b.subscribersLock.Unlock()
+}
+
+func (b *backend) signingWorker() {
+	defer b.signingWg.Done()
+
+	for unsignedMessage := range b.signingQueue {
+		messageID := unsignedMessage.ID()
+		signature, err := b.signMessageWithRetry(unsignedMessage)
+		if err != nil {
+			// The message stays in the database, and GetMessageSignature falls back to signing synchronously and reports the error.
+			log.Error("Failed to sign warp message", "messageID", messageID, "err", err)
+		} else {
+			b.publishSignature(unsignedMessage, signature)
+		}
+
+		b.pendingLock.Lock()
+		close(b.pending[messageID])
+		delete(b.pending, messageID)
+		b.pendingLock.Unlock()
+	}
+}
+
+// enqueue schedules [unsignedMessage] to be signed in the background and returns false
+// if the backend signs synchronously or has been closed.
+func (b *backend) enqueue(unsignedMessage *avalancheWarp.UnsignedMessage) bool {
+	b.closeLock.RLock()
+	defer b.closeLock.RUnlock()
+
+	if b.signingQueue == nil || b.closed {
+		return false
+	}
+
+	messageID := unsignedMessage.ID()
+	b.pendingLock.Lock()
+	if _, ok := b.pending[messageID]; ok {
+		b.pendingLock.Unlock()
+		return true
+	}
+	b.pending[messageID] = make(chan struct{})
+	b.pendingLock.Unlock()
+
+	b.signingQueue <- unsignedMessage
+	return true
+}
+
+// waitForPending blocks until a queued signing of [messageID], if any, has finished or [ctx] is done.
+func (b *backend) waitForPending(ctx context.Context, messageID ids.ID) error {
+	b.pendingLock.Lock()
+	done, ok := b.pending[messageID]
+	b.pendingLock.Unlock()
+	if !ok {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// verifyMessage checks that [unsignedMessage] is within the maximum message size and parses,
+// so malformed messages are rejected before they are stored or signed.
+func (b *backend) verifyMessage(unsignedMessage *avalancheWarp.UnsignedMessage) error {
+	messageBytes := unsignedMessage.Bytes()
+	if b.maxMessageSize > 0 && len(messageBytes) > b.maxMessageSize {
+		return fmt.Errorf("%w: %d bytes > max %d bytes", ErrMessageTooLarge, len(messageBytes), b.maxMessageSize)
+	}
+	parsed, err := avalancheWarp.ParseUnsignedMessage(messageBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+	}
+	if parsed.ID() != unsignedMessage.ID() {
+		return fmt.Errorf("%w: %w", ErrInvalidMessage, errMessageIDMismatch)
+	}
+	return nil
+}
+
+// signMessage signs [unsignedMessage] and adds the signature to the cache.
+func (b *backend) signMessage(unsignedMessage *avalancheWarp.UnsignedMessage) ([bls.SignatureLen]byte, error) {
+	var signature [bls.SignatureLen]byte
+	b.stats.IncMessageSign()
+	sig, err := b.warpSigner.Sign(unsignedMessage)
+	if err != nil {
+		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to sign warp message: %w", err)
+	}
+
+	copy(signature[:], sig)
+	messageID := unsignedMessage.ID()
+	b.messageSignatureCache.Put(messageID, signature)
+	if b.persistSignatures {
+		// The signature is still usable if persisting it fails, so only log the error.
+		value := append(b.publicKeyBytes[:len(b.publicKeyBytes):len(b.publicKeyBytes)], signature[:]...)
+		if err := b.db.Put(signatureKey(messageID), value); err != nil {
+			log.Warn("Failed to persist warp message signature", "messageID", messageID, "err", err)
+		}
+	}
+	return signature, nil
+}
+
+// signMessageWithRetry signs [unsignedMessage] with signMessage, making up to [signAttempts] attempts
+// so that transient signer failures do not leave an added message unsigned.
+func (b *backend) signMessageWithRetry(unsignedMessage *avalancheWarp.UnsignedMessage) ([bls.SignatureLen]byte, error) {
+	var err error
+	for attempt := 1; attempt <= b.signAttempts; attempt++ {
+		if attempt > 1 {
+			log.Debug("Retrying warp message signing", "messageID", unsignedMessage.ID(), "attempt", attempt, "err", err)
+			time.Sleep(b.signRetryDelay)
+		}
+		var signature [bls.SignatureLen]byte
+		signature, err = b.signMessage(unsignedMessage)
+		if err == nil {
+			return signature, nil
+		}
+	}
+	return [bls.SignatureLen]byte{}, fmt.Errorf("failed after %d attempts: %w", b.signAttempts, err)
+}
+
+// getPersistedSignature returns the signature of [messageID] stored in the database, if signatures are
+// persisted and the stored signature was made with the current public key. A signature stored under a
+// different key (e.g. after the node's BLS key changed) is deleted so the message is signed again.
+func (b *backend) getPersistedSignature(messageID ids.ID) ([bls.SignatureLen]byte, bool) {
+	if !b.persistSignatures {
+		return [bls.SignatureLen]byte{}, false
+	}
+	value, err := b.db.Get(signatureKey(messageID))
+	if err != nil {
+		if !errors.Is(err, database.ErrNotFound) {
+			log.Warn("Failed to get persisted warp message signature", "messageID", messageID, "err", err)
+		}
+		return [bls.SignatureLen]byte{}, false
+	}
+	if len(value) != bls.PublicKeyLen+bls.SignatureLen || !bytes.Equal(value[:bls.PublicKeyLen], b.publicKeyBytes) {
+		log.Debug("Discarding persisted warp message signature made with a different key", "messageID", messageID)
+		if err := b.db.Delete(signatureKey(messageID)); err != nil {
+			log.Warn("Failed to delete persisted warp message signature", "messageID", messageID, "err", err)
+		}
+		return [bls.SignatureLen]byte{}, false
+	}
+	var signature [bls.SignatureLen]byte
+	copy(signature[:], value[bls.PublicKeyLen:])
+	return signature, true
+}
+
+// recentlyMissed returns true if [messageID] was found missing from the database within the miss cache TTL.
+func (b *backend) recentlyMissed(messageID ids.ID) bool {
+	if b.missCache == nil {
+		return false
+	}
+	expiry, ok := b.missCache.Get(messageID)
+	if !ok {
+		return false
+	}
+	if !b.clock.Time().Before(expiry) {
+		b.missCache.Evict(messageID)
+		return false
+	}
+	b.stats.IncMessageMissCacheHit()
+	return true
+}
+
+// recordMiss caches that [messageID] was found missing from the database, if misses are cached.
+func (b *backend) recordMiss(messageID ids.ID) {
+	if b.missCache != nil {
+		b.missCache.Put(messageID, b.clock.Time().Add(b.missCacheTTL))
+	}
+}
+
+func (b *backend) HasMessage(ctx context.Context, messageID ids.ID) (bool, error) {
+	if _, ok := b.messageSignatureCache.Get(messageID); ok {
+		return true, nil
+	}
+	if _, ok := b.messageCache.Get(messageID); ok {
+		return true, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return b.db.Has(messageID[:])
+}
+
+func (b *backend) DeleteMessage(ctx context.Context, messageID ids.ID) error {
+	// Wait for a queued signature so the signing worker does not re-cache it after the delete.
+	if err := b.waitForPending(ctx, messageID); err != nil {
+		return err
+	}
+
+	if err := b.db.DeleteAll(messageKeys(messageID)); err != nil {
+		return fmt.Errorf("failed to delete warp message %s: %w", messageID, err)
 	}
+	b.messageSignatureCache.Evict(messageID)
+	b.messageCache.Evict(messageID)
+	log.Debug("Deleted warp message from backend", "messageID", messageID)
+	return nil
+}
+
+func (b *backend) HealthCheck(ctx context.Context) error {
+	if _, err := b.db.HealthCheck(ctx); err != nil {
+		return fmt.Errorf("warp backend db is unhealthy: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(b.networkID, b.sourceChainID, healthCheckPayload)
+	if err != nil {
+		return fmt.Errorf("failed to create health check message: %w", err)
+	}
+	sigBytes, err := b.warpSigner.Sign(unsignedMessage)
+	if err != nil {
+		return fmt.Errorf("failed to sign health check message: %w", err)
+	}
+	if b.publicKey == nil {
+		return nil
+	}
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse health check signature: %w", err)
+	}
+	if !bls.Verify(b.publicKey, sig, unsignedMessage.Bytes()) {
+		return errHealthCheckSignature
+	}
+	return nil
+}
+
+func (b *backend) CurrentPublicKey() *bls.PublicKey {
+	return b.publicKey
 }
 
 func (b *backend) Clear() error {
 	b.messageSignatureCache.Flush()
+	if b.missCache != nil {
+		b.missCache.Flush()
+	}
 	b.blockSignatureCache.Flush()
 	b.messageCache.Flush()
-	return database.Clear(b.db, batchSize)
+
+	keys := make([][]byte, 0)
+	if err := b.db.Iterate(func(key []byte, _ []byte) error {
+		keys = append(keys, bytes.Clone(key))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to iterate warp backend db: %w", err)
+	}
+	for start := 0; start < len(keys); start += clearBatchKeys {
+		end := start + clearBatchKeys
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if err := b.db.DeleteAll(keys[start:end]); err != nil {
+			return fmt.Errorf("failed to clear warp backend db: %w", err)
+		}
+	}
+	return nil
 }
 
 func (b *backend) AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage) error {
+	if err := b.verifyMessage(unsignedMessage); err != nil {
+		b.stats.IncMessageRejected()
+		return err
+	}
 	messageID := unsignedMessage.ID()
 
+	// BLS signatures are deterministic, so a message that is already tracked (e.g. when a block is
+	// re-processed) does not need to be written or signed again. If only the database has the message,
+	// its signature is produced lazily by GetMessageSignature.
+	if _, ok := b.messageSignatureCache.Get(messageID); ok {
+		return nil
+	}
+	has, err := b.db.Has(messageID[:])
+	if err != nil {
+		return fmt.Errorf("failed to check warp message in db: %w", err)
+	}
+	if has {
+		log.Debug("Skipping already added warp message", "messageID", messageID)
+		return nil
+	}
+
 	// In the case when a node restarts, and possibly changes its bls key, the cache gets emptied but the database does not.
 	// So to avoid having incorrect signatures saved in the database after a bls key change, we save the full message in the database.
 	// Whereas for the cache, after the node restart, the cache would be emptied so we can directly save the signatures.
 	if err := b.db.Put(messageID[:], unsignedMessage.Bytes()); err != nil {
 		return fmt.Errorf("failed to put warp signature in db: %w", err)
 	}
-
-	var signature [bls.SignatureLen]byte
-	sig, err := b.warpSigner.Sign(unsignedMessage)
-	if err != nil {
-		return fmt.Errorf("failed to sign warp message: %w", err)
+	if err := database.PutTimestamp(b.db, timestampKey(messageID), b.clock.Time()); err != nil {
+		return fmt.Errorf("failed to put warp message timestamp in db: %w", err)
+	}
+	if b.missCache != nil {
+		b.missCache.Evict(messageID)
 	}
 
-	copy(signature[:], sig)
-	b.messageSignatureCache.Put(messageID, signature)
 	log.Debug("Adding warp message to backend", "messageID", messageID)
+	if b.enqueue(unsignedMessage) {
+		return nil
+	}
+	signature, err := b.signMessageWithRetry(unsignedMessage)
+	if err != nil {
+		// An error means the message was not added, so remove it from the database rather than leaving it
+		// to be signed lazily. Adding it again, e.g. when the block is accepted again, signs it from scratch.
+		if rollbackErr := b.DeleteMessage(context.Background(), messageID); rollbackErr != nil {
+			log.Error("Failed to roll back unsigned warp message", "messageID", messageID, "err", rollbackErr)
+		}
+		return err
+	}
+	b.publishSignature(unsignedMessage, signature)
 	return nil
 }
 
-func (b *backend) GetMessageSignature(messageID ids.ID) ([bls.SignatureLen]byte, error) {
+func (b *backend) SubscribeNewSignatures(ctx context.Context) (<-chan SignedMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan SignedMessage, subscriptionBufferSize)
+	b.subscribersLock.Lock()
+	if b.subscribers == nil {
+		b.subscribersLock.Unlock()
+		return nil, errBackendClosed
+	}
+	b.subscribers[ch] = struct{}{}
+	b.subscribersLock.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.unsubscribe(ch)
+		case <-b.shutdown:
+			// Close closes the subscription.
+		}
+	}()
+	return ch, nil
+}
+
+// unsubscribe removes and closes the subscription [ch], unless it was already closed by Close.
+func (b *backend) unsubscribe(ch chan SignedMessage) {
+	b.subscribersLock.Lock()
+	defer b.subscribersLock.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// publishSignature sends [signature] of [unsignedMessage] to every subscription without blocking,
+// dropping it for subscribers whose buffer is full.
+func (b *backend) publishSignature(unsignedMessage *avalancheWarp.UnsignedMessage, signature [bls.SignatureLen]byte) {
+	b.subscribersLock.Lock()
+	defer b.subscribersLock.Unlock()
+
+	signedMessage := SignedMessage{
+		Message:   unsignedMessage,
+		Signature: signature,
+	}
+	for ch := range b.subscribers {
+		select {
+		case ch <- signedMessage:
+		default:
+			log.Warn("Dropping warp signature for slow subscriber", "messageID", unsignedMessage.ID())
+		}
+	}
+}
+
+func (b *backend) GetMessageSignature(ctx context.Context, messageID ids.ID) ([bls.SignatureLen]byte, error) {
 	log.Debug("Getting warp message from backend", "messageID", messageID)
+	sig, unsignedMessage, err := b.getStoredSignature(ctx, messageID)
+	if err != nil || unsignedMessage == nil {
+		return sig, err
+	}
+	if err := ctx.Err(); err != nil {
+		return [bls.SignatureLen]byte{}, err
+	}
+	return b.signMessage(unsignedMessage)
+}
+
+// getStoredSignature returns the signature of [messageID] from the cache or the database. If the message
+// has no stored signature, it returns the message to sign instead.
+func (b *backend) getStoredSignature(ctx context.Context, messageID ids.ID) ([bls.SignatureLen]byte, *avalancheWarp.UnsignedMessage, error) {
+	if err := b.waitForPending(ctx, messageID); err != nil {
+		return [bls.SignatureLen]byte{}, nil, err
+	}
 	if sig, ok := b.messageSignatureCache.Get(messageID); ok {
-		return sig, nil
+		b.stats.IncMessageSignatureCacheHit()
+		return sig, nil, nil
+	}
+	b.stats.IncMessageSignatureCacheMiss()
+
+	if b.recentlyMissed(messageID) {
+		return [bls.SignatureLen]byte{}, nil, fmt.Errorf("failed to get warp message %s from db: %w", messageID.String(), database.ErrNotFound)
+	}
+	if sig, ok := b.getPersistedSignature(messageID); ok {
+		b.stats.IncMessageSignatureDBHit()
+		b.messageSignatureCache.Put(messageID, sig)
+		return sig, nil, nil
 	}
 
 	unsignedMessage, err := b.GetMessage(messageID)
 	if err != nil {
-		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to get warp message %s from db: %w", messageID.String(), err)
+		return [bls.SignatureLen]byte{}, nil, fmt.Errorf("failed to get warp message %s from db: %w", messageID.String(), err)
 	}
+	return [bls.SignatureLen]byte{}, unsignedMessage, nil
+}
 
-	var signature [bls.SignatureLen]byte
-	sig, err := b.warpSigner.Sign(unsignedMessage)
-	if err != nil {
-		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to sign warp message: %w", err)
+func (b *backend) GetMessageSignatures(ctx context.Context, messageIDs []ids.ID) ([][bls.SignatureLen]byte, []error) {
+	signatures := make([][bls.SignatureLen]byte, len(messageIDs))
+	errs := make([]error, len(messageIDs))
+
+	// Look up every message before signing any, so the stored signatures are not held up by the signer.
+	unsignedMessages := make([]*avalancheWarp.UnsignedMessage, len(messageIDs))
+	for i, messageID := range messageIDs {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		signatures[i], unsignedMessages[i], errs[i] = b.getStoredSignature(ctx, messageID)
 	}
 
-	copy(signature[:], sig)
-	b.messageSignatureCache.Put(messageID, signature)
-	return signature, nil
+	// Sign the remaining messages, each only once even if it was requested several times.
+	type signResult struct {
+		signature [bls.SignatureLen]byte
+		err       error
+	}
+	signed := make(map[ids.ID]signResult)
+	for i, unsignedMessage := range unsignedMessages {
+		if unsignedMessage == nil {
+			continue
+		}
+		result, ok := signed[messageIDs[i]]
+		if !ok {
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				continue
+			}
+			result.signature, result.err = b.signMessage(unsignedMessage)
+			signed[messageIDs[i]] = result
+		}
+		signatures[i], errs[i] = result.signature, result.err
+	}
+	return signatures, errs
 }
 
-func (b *backend) GetBlockSignature(blockID ids.ID) ([bls.SignatureLen]byte, error) {
+func (b *backend) GetBlockSignature(ctx context.Context, blockID ids.ID) ([bls.SignatureLen]byte, error) {
 	log.Debug("Getting block from backend", "blockID", blockID)
 	if sig, ok := b.blockSignatureCache.Get(blockID); ok {
+		b.stats.IncBlockSignatureCacheHit()
 		return sig, nil
 	}
+	b.stats.IncBlockSignatureCacheMiss()
 
-	block, err := b.blockClient.GetBlock(context.TODO(), blockID)
+	block, err := b.blockClient.GetBlock(ctx, blockID)
 	if err != nil {
 		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to get block %s: %w", blockID, err)
 	}
@@ -146,6 +759,9 @@ func (b *backend) GetBlockSignature(blockID ids.ID) ([bls.SignatureLen]byte, err
 	if err != nil {
 		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to create new unsigned warp message: %w", err)
 	}
+	if err := ctx.Err(); err != nil {
+		return [bls.SignatureLen]byte{}, err
+	}
 	sig, err := b.warpSigner.Sign(unsignedMessage)
 	if err != nil {
 		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to sign warp message: %w", err)
@@ -161,8 +777,16 @@ func (b *backend) GetMessage(messageID ids.ID) (*avalancheWarp.UnsignedMessage,
 		return message, nil
 	}
 
+	if b.recentlyMissed(messageID) {
+		return nil, fmt.Errorf("failed to get warp message %s from db: %w", messageID.String(), database.ErrNotFound)
+	}
+
+	b.stats.IncMessageDBRead()
 	unsignedMessageBytes, err := b.db.Get(messageID[:])
 	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			b.recordMiss(messageID)
+		}
 		return nil, fmt.Errorf("failed to get warp message %s from db: %w", messageID.String(), err)
 	}
 
@@ -170,7 +794,120 @@ func (b *backend) GetMessage(messageID ids.ID) (*avalancheWarp.UnsignedMessage,
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse unsigned message %s: %w", messageID.String(), err)
 	}
+	// Guard against signing corrupted or misaligned db entries.
+	if parsedID := unsignedMessage.ID(); parsedID != messageID {
+		return nil, fmt.Errorf("%w: expected %s but db entry has %s", errMessageIDMismatch, messageID, parsedID)
+	}
 	b.messageCache.Put(messageID, unsignedMessage)
 
 	return unsignedMessage, nil
 }
+
+func (b *backend) GetMessageIDs(ctx context.Context) ([]ids.ID, error) {
+	messageIDs := make([]ids.ID, 0)
+	err := b.db.Iterate(func(key []byte, _ []byte) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(key) != ids.IDLen {
+			return nil
+		}
+		messageID, err := ids.ToID(key)
+		if err != nil {
+			return fmt.Errorf("failed to parse warp message ID from db key %x: %w", key, err)
+		}
+		messageIDs = append(messageIDs, messageID)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate warp messages: %w", err)
+	}
+	return messageIDs, nil
+}
+
+func (b *backend) Prune(ctx context.Context, before time.Time) (int, error) {
+	messageIDs, err := b.GetMessageIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	prunedKeys := make([][]byte, 0)
+	pruned := make([]ids.ID, 0)
+	for _, messageID := range messageIDs {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if _, ok := b.messageSignatureCache.Get(messageID); ok {
+			continue
+		}
+		// Messages added before timestamps were tracked have no timestamp. When they were added is
+		// unknown, so they are timestamped now rather than being treated as expired.
+		timestamp, err := database.GetTimestamp(b.db, timestampKey(messageID))
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			if err := database.PutTimestamp(b.db, timestampKey(messageID), b.clock.Time()); err != nil {
+				return 0, fmt.Errorf("failed to put warp message %s timestamp in db: %w", messageID, err)
+			}
+			continue
+		case err != nil:
+			return 0, fmt.Errorf("failed to get warp message %s timestamp from db: %w", messageID, err)
+		case !timestamp.Before(before):
+			continue
+		}
+
+		prunedKeys = append(prunedKeys, messageKeys(messageID)...)
+		pruned = append(pruned, messageID)
+	}
+	if err := b.db.DeleteAll(prunedKeys); err != nil {
+		return 0, fmt.Errorf("failed to prune warp messages: %w", err)
+	}
+	for _, messageID := range pruned {
+		b.messageCache.Evict(messageID)
+	}
+	log.Debug("Pruned warp messages from backend", "count", len(pruned), "before", before)
+	return len(pruned), nil
+}
+
+func (b *backend) ReSignAll(ctx context.Context) error {
+	messageIDs, err := b.GetMessageIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		start  = time.Now()
+		logged = time.Now()
+	)
+	log.Info("Re-signing warp messages", "count", len(messageIDs))
+	for i, messageID := range messageIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		unsignedMessage, err := b.GetMessage(messageID)
+		if err != nil {
+			return err
+		}
+		if _, err := b.signMessage(unsignedMessage); err != nil {
+			return fmt.Errorf("failed to re-sign warp message %s: %w", messageID, err)
+		}
+		if time.Since(logged) > reSignLogInterval {
+			log.Info("Re-signing warp messages", "signed", i+1, "count", len(messageIDs), "elapsed", time.Since(start))
+			logged = time.Now()
+		}
+	}
+	log.Info("Re-signed warp messages", "count", len(messageIDs), "elapsed", time.Since(start))
+	return nil
+}
+
+// messageKeys returns the db keys of [messageID] and the data stored alongside it.
+func messageKeys(messageID ids.ID) [][]byte {
+	return [][]byte{messageID[:], timestampKey(messageID), signatureKey(messageID)}
+}
+
+func timestampKey(messageID ids.ID) []byte {
+	return append(timestampPrefix[:len(timestampPrefix):len(timestampPrefix)], messageID[:]...)
+}
+
+func signatureKey(messageID ids.ID) []byte {
+	return append(signaturePrefix[:len(signaturePrefix):len(signaturePrefix)], messageID[:]...)
+}
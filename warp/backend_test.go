@@ -7,7 +7,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -18,7 +20,9 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 )
 
 var (
@@ -33,7 +37,12 @@ func TestClearDB(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backendIntf := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500)
+	backendIntf := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
 	backend, ok := backendIntf.(*backend)
 	require.True(t, ok)
 
@@ -50,7 +59,7 @@ func TestClearDB(t *testing.T) {
 		err = backend.AddMessage(unsignedMsg)
 		require.NoError(t, err)
 		// ensure that the message was added
-		_, err = backend.GetMessageSignature(messageID)
+		_, err = backend.GetMessageSignature(context.Background(), messageID)
 		require.NoError(t, err)
 	}
 
@@ -65,7 +74,7 @@ func TestClearDB(t *testing.T) {
 
 	// ensure all messages have been deleted
 	for _, messageID := range messageIDs {
-		_, err := backend.GetMessageSignature(messageID)
+		_, err := backend.GetMessageSignature(context.Background(), messageID)
 		require.ErrorContains(t, err, "failed to get warp message")
 	}
 }
@@ -76,7 +85,12 @@ func TestAddAndGetValidMessage(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500)
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
 
 	// Create a new unsigned message and add it to the warp backend.
 	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
@@ -86,7 +100,7 @@ func TestAddAndGetValidMessage(t *testing.T) {
 
 	// Verify that a signature is returned successfully, and compare to expected signature.
 	messageID := unsignedMsg.ID()
-	signature, err := backend.GetMessageSignature(messageID)
+	signature, err := backend.GetMessageSignature(context.Background(), messageID)
 	require.NoError(t, err)
 
 	expectedSig, err := warpSigner.Sign(unsignedMsg)
@@ -100,13 +114,18 @@ func TestAddAndGetUnknownMessage(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500)
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
 	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
 	require.NoError(t, err)
 
 	// Try getting a signature for a message that was not added.
 	messageID := unsignedMsg.ID()
-	_, err = backend.GetMessageSignature(messageID)
+	_, err = backend.GetMessageSignature(context.Background(), messageID)
 	require.Error(t, err)
 }
 
@@ -133,7 +152,13 @@ func TestGetBlockSignature(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backend := NewBackend(networkID, sourceChainID, warpSigner, testVM, db, 500)
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		BlockClient:   testVM,
+		CacheSize:     500,
+	})
 
 	blockHashPayload, err := payload.NewHash(blkID)
 	require.NoError(err)
@@ -142,11 +167,11 @@ func TestGetBlockSignature(t *testing.T) {
 	expectedSig, err := warpSigner.Sign(unsignedMessage)
 	require.NoError(err)
 
-	signature, err := backend.GetBlockSignature(blkID)
+	signature, err := backend.GetBlockSignature(context.Background(), blkID)
 	require.NoError(err)
 	require.Equal(expectedSig, signature[:])
 
-	_, err = backend.GetBlockSignature(ids.GenerateTestID())
+	_, err = backend.GetBlockSignature(context.Background(), ids.GenerateTestID())
 	require.Error(err)
 }
 
@@ -158,7 +183,11 @@ func TestZeroSizedCache(t *testing.T) {
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
 
 	// Verify zero sized cache works normally, because the lru cache will be initialized to size 1 for any size parameter <= 0.
-	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 0)
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+	})
 
 	// Create a new unsigned message and add it to the warp backend.
 	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
@@ -168,10 +197,949 @@ func TestZeroSizedCache(t *testing.T) {
 
 	// Verify that a signature is returned successfully, and compare to expected signature.
 	messageID := unsignedMsg.ID()
-	signature, err := backend.GetMessageSignature(messageID)
+	signature, err := backend.GetMessageSignature(context.Background(), messageID)
 	require.NoError(t, err)
 
 	expectedSig, err := warpSigner.Sign(unsignedMsg)
 	require.NoError(t, err)
 	require.Equal(t, expectedSig, signature[:])
 }
+
+func TestGetMessageIDs(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+
+	messageIDs, err := backend.GetMessageIDs(context.Background())
+	require.NoError(err)
+	require.Empty(messageIDs)
+
+	expectedMessageIDs := []ids.ID{}
+	for _, payload := range [][]byte{[]byte("test1"), []byte("test2"), []byte("test3")} {
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, payload)
+		require.NoError(err)
+		require.NoError(backend.AddMessage(unsignedMsg))
+		expectedMessageIDs = append(expectedMessageIDs, unsignedMsg.ID())
+	}
+
+	messageIDs, err = backend.GetMessageIDs(context.Background())
+	require.NoError(err)
+	require.ElementsMatch(expectedMessageIDs, messageIDs)
+}
+
+func TestGetMessageSignatures(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := &countingSigner{Signer: avalancheWarp.NewSigner(sk, networkID, sourceChainID)}
+	backendIntf := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	knownMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, []byte("known"))
+	require.NoError(err)
+	require.NoError(backend.AddMessage(knownMsg))
+	unknownMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, []byte("unknown"))
+	require.NoError(err)
+
+	// Flush the cache to ensure the signature is re-populated by the batch request.
+	backend.messageSignatureCache.Flush()
+
+	signatures, errs := backend.GetMessageSignatures(context.Background(), []ids.ID{unknownMsg.ID(), knownMsg.ID()})
+	require.Len(signatures, 2)
+	require.Len(errs, 2)
+	require.ErrorContains(errs[0], "failed to get warp message")
+	require.NoError(errs[1])
+
+	expectedSig, err := warpSigner.Signer.Sign(knownMsg)
+	require.NoError(err)
+	require.Equal(expectedSig, signatures[1][:])
+	require.Equal(2, warpSigner.calls)
+
+	cachedSig, ok := backend.messageSignatureCache.Get(knownMsg.ID())
+	require.True(ok)
+	require.Equal(signatures[1], cachedSig)
+
+	// Missing messages are reported at their index with a zero signature, wherever they appear.
+	signatures, errs = backend.GetMessageSignatures(context.Background(), []ids.ID{knownMsg.ID(), unknownMsg.ID(), knownMsg.ID()})
+	require.NoError(errs[0])
+	require.ErrorIs(errs[1], database.ErrNotFound)
+	require.Zero(signatures[1])
+	require.NoError(errs[2])
+	require.Equal(signatures[0], signatures[2])
+	require.Equal(2, warpSigner.calls)
+
+	// A message requested several times without a stored signature is signed once.
+	backend.messageSignatureCache.Flush()
+	signatures, errs = backend.GetMessageSignatures(context.Background(), []ids.ID{knownMsg.ID(), knownMsg.ID()})
+	require.NoError(errs[0])
+	require.NoError(errs[1])
+	require.Equal(expectedSig, signatures[0][:])
+	require.Equal(expectedSig, signatures[1][:])
+	require.Equal(3, warpSigner.calls)
+
+	// Every message is given the error of a done context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	signatures, errs = backend.GetMessageSignatures(ctx, []ids.ID{knownMsg.ID(), unknownMsg.ID()})
+	for i := range errs {
+		require.ErrorIs(errs[i], context.Canceled)
+		require.Zero(signatures[i])
+	}
+
+	signatures, errs = backend.GetMessageSignatures(context.Background(), nil)
+	require.Empty(signatures)
+	require.Empty(errs)
+}
+
+func TestBackendMetrics(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	registry := metrics.NewRegistry()
+	backendIntf := NewBackend(db, BackendConfig{
+		NetworkID:       networkID,
+		SourceChainID:   sourceChainID,
+		Signer:          warpSigner,
+		CacheSize:       500,
+		MetricsRegistry: registry,
+	})
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	require.NoError(backend.AddMessage(unsignedMsg))
+
+	// Signature is cached by AddMessage.
+	_, err = backend.GetMessageSignature(context.Background(), unsignedMsg.ID())
+	require.NoError(err)
+
+	// Flush both caches to force a DB read and re-sign.
+	backend.messageSignatureCache.Flush()
+	backend.messageCache.Flush()
+	_, err = backend.GetMessageSignature(context.Background(), unsignedMsg.ID())
+	require.NoError(err)
+
+	require.EqualValues(1, backend.stats.messageSignatureCacheHit.Count())
+	require.EqualValues(1, backend.stats.messageSignatureCacheMiss.Count())
+	require.EqualValues(1, backend.stats.messageDBRead.Count())
+	require.EqualValues(2, backend.stats.messageSign.Count())
+	require.NotNil(registry.Get("warp_backend_message_signature_cache_hit"))
+}
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backendIntf := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	start := time.Unix(1_000_000, 0)
+	addMessage := func(payload []byte, addedAt time.Time) *avalancheWarp.UnsignedMessage {
+		backend.clock.Set(addedAt)
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, payload)
+		require.NoError(err)
+		require.NoError(backend.AddMessage(unsignedMsg))
+		return unsignedMsg
+	}
+	oldMsg := addMessage([]byte("old"), start)
+	oldCachedMsg := addMessage([]byte("old cached"), start)
+	newMsg := addMessage([]byte("new"), start.Add(time.Hour))
+
+	// Only keep the signature of [oldCachedMsg] in the cache.
+	backend.messageSignatureCache.Flush()
+	_, err = backend.GetMessageSignature(context.Background(), oldCachedMsg.ID())
+	require.NoError(err)
+
+	pruned, err := backend.Prune(context.Background(), start.Add(time.Minute))
+	require.NoError(err)
+	require.Equal(1, pruned)
+
+	_, err = backend.GetMessage(oldMsg.ID())
+	require.ErrorContains(err, "failed to get warp message")
+	_, err = backend.GetMessage(oldCachedMsg.ID())
+	require.NoError(err)
+	_, err = backend.GetMessage(newMsg.ID())
+	require.NoError(err)
+
+	messageIDs, err := backend.GetMessageIDs(context.Background())
+	require.NoError(err)
+	require.ElementsMatch([]ids.ID{oldCachedMsg.ID(), newMsg.ID()}, messageIDs)
+
+	// Once evicted from the cache, [oldCachedMsg] is pruned as well.
+	backend.messageSignatureCache.Flush()
+	pruned, err = backend.Prune(context.Background(), start.Add(time.Minute))
+	require.NoError(err)
+	require.Equal(1, pruned)
+
+	messageIDs, err = backend.GetMessageIDs(context.Background())
+	require.NoError(err)
+	require.Equal([]ids.ID{newMsg.ID()}, messageIDs)
+}
+
+func TestPruneMessageWithoutTimestamp(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	// Seed a message as stored before timestamps were tracked.
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	messageID := unsignedMsg.ID()
+	require.NoError(db.Put(messageID[:], unsignedMsg.Bytes()))
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backendIntf := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	start := time.Unix(1_000_000, 0)
+	backend.clock.Set(start)
+	pruned, err := backend.Prune(context.Background(), start.Add(time.Hour))
+	require.NoError(err)
+	require.Zero(pruned)
+	_, err = backend.GetMessage(messageID)
+	require.NoError(err)
+
+	// The message is timestamped when first seen by Prune, and pruned once that time has passed.
+	timestamp, err := database.GetTimestamp(db, timestampKey(messageID))
+	require.NoError(err)
+	require.Equal(start.Unix(), timestamp.Unix())
+	pruned, err = backend.Prune(context.Background(), start)
+	require.NoError(err)
+	require.Zero(pruned)
+	pruned, err = backend.Prune(context.Background(), start.Add(time.Second))
+	require.NoError(err)
+	require.Equal(1, pruned)
+	has, err := db.Has(messageID[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestGetCorruptedMessage(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+	})
+
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	otherMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, []byte("other"))
+	require.NoError(err)
+
+	// Store a valid message under the wrong key.
+	messageID := unsignedMsg.ID()
+	require.NoError(db.Put(messageID[:], otherMsg.Bytes()))
+
+	_, err = backend.GetMessageSignature(context.Background(), messageID)
+	require.ErrorIs(err, errMessageIDMismatch)
+}
+
+func TestAsyncSigning(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:          networkID,
+		SourceChainID:      sourceChainID,
+		Signer:             warpSigner,
+		CacheSize:          500,
+		SigningConcurrency: 2,
+	})
+
+	unsignedMsgs := make([]*avalancheWarp.UnsignedMessage, 0)
+	for _, payload := range [][]byte{[]byte("test1"), []byte("test2"), []byte("test3"), []byte("test4")} {
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, payload)
+		require.NoError(err)
+		require.NoError(backend.AddMessage(unsignedMsg))
+		unsignedMsgs = append(unsignedMsgs, unsignedMsg)
+	}
+
+	for _, unsignedMsg := range unsignedMsgs {
+		signature, err := backend.GetMessageSignature(context.Background(), unsignedMsg.ID())
+		require.NoError(err)
+		expectedSig, err := warpSigner.Sign(unsignedMsg)
+		require.NoError(err)
+		require.Equal(expectedSig, signature[:])
+	}
+
+	backend.Close()
+
+	// Messages added after Close are signed synchronously.
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	require.NoError(backend.AddMessage(unsignedMsg))
+	_, err = backend.GetMessageSignature(context.Background(), unsignedMsg.ID())
+	require.NoError(err)
+}
+
+func TestGetSignatureCancelledContext(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backendIntf := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	require.NoError(backend.AddMessage(unsignedMsg))
+	backend.messageSignatureCache.Flush()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = backend.GetMessageSignature(ctx, unsignedMsg.ID())
+	require.ErrorIs(err, context.Canceled)
+	_, ok = backend.messageSignatureCache.Get(unsignedMsg.ID())
+	require.False(ok)
+
+	_, err = backend.GetMessageSignature(context.Background(), unsignedMsg.ID())
+	require.NoError(err)
+}
+
+// countingSigner wraps a Signer and records how many times Sign is called.
+type countingSigner struct {
+	avalancheWarp.Signer
+	calls int
+}
+
+func (s *countingSigner) Sign(msg *avalancheWarp.UnsignedMessage) ([]byte, error) {
+	s.calls++
+	return s.Signer.Sign(msg)
+}
+
+func TestAddDuplicateMessage(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := &countingSigner{Signer: avalancheWarp.NewSigner(sk, networkID, sourceChainID)}
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	require.NoError(backend.AddMessage(unsignedMsg))
+	require.NoError(backend.AddMessage(unsignedMsg))
+	require.Equal(1, warpSigner.calls)
+
+	// A message that is only in the database is not re-signed when added again.
+	backend = NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+	require.NoError(backend.AddMessage(unsignedMsg))
+	require.Equal(1, warpSigner.calls)
+
+	_, err = backend.GetMessageSignature(context.Background(), unsignedMsg.ID())
+	require.NoError(err)
+	require.Equal(2, warpSigner.calls)
+}
+
+func TestDeleteMessage(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	messageID := unsignedMsg.ID()
+	require.NoError(backend.AddMessage(unsignedMsg))
+	_, err = backend.GetMessageSignature(context.Background(), messageID)
+	require.NoError(err)
+
+	require.NoError(backend.DeleteMessage(context.Background(), messageID))
+	_, err = backend.GetMessageSignature(context.Background(), messageID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	messageIDs, err := backend.GetMessageIDs(context.Background())
+	require.NoError(err)
+	require.Empty(messageIDs)
+
+	// Deleting an unknown message is a no-op.
+	require.NoError(backend.DeleteMessage(context.Background(), ids.GenerateTestID()))
+}
+
+func TestHasMessage(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := &countingSigner{Signer: avalancheWarp.NewSigner(sk, networkID, sourceChainID)}
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	messageID := unsignedMsg.ID()
+
+	has, err := backend.HasMessage(context.Background(), messageID)
+	require.NoError(err)
+	require.False(has)
+
+	require.NoError(backend.AddMessage(unsignedMsg))
+	has, err = backend.HasMessage(context.Background(), messageID)
+	require.NoError(err)
+	require.True(has)
+
+	// A message that is only in the database is found without being signed.
+	backend = NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+	})
+	has, err = backend.HasMessage(context.Background(), messageID)
+	require.NoError(err)
+	require.True(has)
+	require.Equal(1, warpSigner.calls)
+}
+
+func TestPersistedSignatures(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := &countingSigner{Signer: avalancheWarp.NewSigner(sk, networkID, sourceChainID)}
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            warpSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(sk),
+		PersistSignatures: true,
+	})
+
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	messageID := unsignedMsg.ID()
+	require.NoError(backend.AddMessage(unsignedMsg))
+	require.Equal(1, warpSigner.calls)
+
+	// Persisted signature keys are not reported as messages.
+	messageIDs, err := backend.GetMessageIDs(context.Background())
+	require.NoError(err)
+	require.Equal([]ids.ID{messageID}, messageIDs)
+
+	// After a restart the signature is loaded from the database instead of being re-signed.
+	backend = NewBackend(db, BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            warpSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(sk),
+		PersistSignatures: true,
+	})
+	signature, err := backend.GetMessageSignature(context.Background(), messageID)
+	require.NoError(err)
+	require.Equal(1, warpSigner.calls)
+	expectedSig, err := warpSigner.Signer.Sign(unsignedMsg)
+	require.NoError(err)
+	require.Equal(expectedSig, signature[:])
+
+	// After a key change the persisted signature is discarded and the message is re-signed.
+	newSk, err := bls.NewSecretKey()
+	require.NoError(err)
+	newSigner := &countingSigner{Signer: avalancheWarp.NewSigner(newSk, networkID, sourceChainID)}
+	backend = NewBackend(db, BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            newSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(newSk),
+		PersistSignatures: true,
+	})
+	signature, err = backend.GetMessageSignature(context.Background(), messageID)
+	require.NoError(err)
+	require.Equal(1, newSigner.calls)
+	expectedSig, err = newSigner.Signer.Sign(unsignedMsg)
+	require.NoError(err)
+	require.Equal(expectedSig, signature[:])
+
+	// Deleting the message also removes its persisted signature.
+	require.NoError(backend.DeleteMessage(context.Background(), messageID))
+	has, err := db.Has(signatureKey(messageID))
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestCurrentPublicKey(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            warpSigner,
+		CacheSize:         500,
+		PersistSignatures: true,
+	})
+	require.Nil(backend.CurrentPublicKey())
+
+	backend = NewBackend(db, BackendConfig{
+		NetworkID:     networkID,
+		SourceChainID: sourceChainID,
+		Signer:        warpSigner,
+		CacheSize:     500,
+		PublicKey:     pk,
+	})
+	require.Equal(pk, backend.CurrentPublicKey())
+
+	// Signatures are not persisted unless enabled.
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	require.NoError(backend.AddMessage(unsignedMsg))
+	has, err := db.Has(signatureKey(unsignedMsg.ID()))
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestAddMessageRejected(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := &countingSigner{Signer: avalancheWarp.NewSigner(sk, networkID, sourceChainID)}
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	backendIntf := NewBackend(db, BackendConfig{
+		NetworkID:       networkID,
+		SourceChainID:   sourceChainID,
+		Signer:          warpSigner,
+		CacheSize:       500,
+		MetricsRegistry: metrics.NewRegistry(),
+		MaxMessageSize:  len(unsignedMsg.Bytes()),
+	})
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	// A message at the maximum size is accepted.
+	require.NoError(backend.AddMessage(unsignedMsg))
+	require.Equal(1, warpSigner.calls)
+
+	largeMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, append(testPayload, 0))
+	require.NoError(err)
+	err = backend.AddMessage(largeMsg)
+	require.ErrorIs(err, ErrMessageTooLarge)
+
+	// A message that was never initialized has no bytes to parse.
+	invalidMsg := &avalancheWarp.UnsignedMessage{NetworkID: networkID, SourceChainID: sourceChainID, Payload: testPayload}
+	err = backend.AddMessage(invalidMsg)
+	require.ErrorIs(err, ErrInvalidMessage)
+
+	// Rejected messages are neither stored nor signed, but are counted.
+	has, err := backend.HasMessage(context.Background(), largeMsg.ID())
+	require.NoError(err)
+	require.False(has)
+	require.Equal(1, warpSigner.calls)
+	require.EqualValues(2, backend.stats.messageRejected.Count())
+}
+
+func TestMissCache(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backendIntf := NewBackend(db, BackendConfig{
+		NetworkID:       networkID,
+		SourceChainID:   sourceChainID,
+		Signer:          warpSigner,
+		CacheSize:       500,
+		MetricsRegistry: metrics.NewRegistry(),
+		MissCacheTTL:    time.Second,
+	})
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	start := time.Unix(1_000_000, 0)
+	backend.clock.Set(start)
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	messageID := unsignedMsg.ID()
+
+	// Only the first request for a missing message reads the db.
+	for i := 0; i < 3; i++ {
+		_, err = backend.GetMessageSignature(context.Background(), messageID)
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+	require.EqualValues(1, backend.stats.messageDBRead.Count())
+	require.EqualValues(2, backend.stats.messageMissCacheHit.Count())
+
+	// The miss expires after the TTL.
+	backend.clock.Set(start.Add(time.Second))
+	_, err = backend.GetMessageSignature(context.Background(), messageID)
+	require.ErrorIs(err, database.ErrNotFound)
+	require.EqualValues(2, backend.stats.messageDBRead.Count())
+
+	// Adding the message makes it visible immediately.
+	require.NoError(backend.AddMessage(unsignedMsg))
+	backend.messageSignatureCache.Flush()
+	_, err = backend.GetMessageSignature(context.Background(), messageID)
+	require.NoError(err)
+	require.EqualValues(2, backend.stats.messageMissCacheHit.Count())
+}
+
+func TestReSignAll(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	oldBackend := NewBackend(db, BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            warpSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(sk),
+		PersistSignatures: true,
+	})
+
+	unsignedMsgs := make([]*avalancheWarp.UnsignedMessage, 0, 3)
+	for i := 0; i < 3; i++ {
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, []byte{byte(i)})
+		require.NoError(err)
+		require.NoError(oldBackend.AddMessage(unsignedMsg))
+		unsignedMsgs = append(unsignedMsgs, unsignedMsg)
+	}
+
+	// After a key change every message is re-signed up front with the new key.
+	newSk, err := bls.NewSecretKey()
+	require.NoError(err)
+	newSigner := &countingSigner{Signer: avalancheWarp.NewSigner(newSk, networkID, sourceChainID)}
+	backendIntf := NewBackend(db, BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            newSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(newSk),
+		PersistSignatures: true,
+	})
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+	require.NoError(backend.ReSignAll(context.Background()))
+	require.Equal(len(unsignedMsgs), newSigner.calls)
+	require.Equal(len(unsignedMsgs), backend.messageSignatureCache.Len())
+
+	for _, unsignedMsg := range unsignedMsgs {
+		signature, err := backend.GetMessageSignature(context.Background(), unsignedMsg.ID())
+		require.NoError(err)
+		expectedSig, err := newSigner.Signer.Sign(unsignedMsg)
+		require.NoError(err)
+		require.Equal(expectedSig, signature[:])
+	}
+	require.Equal(len(unsignedMsgs), newSigner.calls)
+
+	// The new signatures are also persisted, so they survive a restart.
+	backendIntf = NewBackend(db, BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            newSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(newSk),
+		PersistSignatures: true,
+	})
+	_, err = backendIntf.GetMessageSignature(context.Background(), unsignedMsgs[0].ID())
+	require.NoError(err)
+	require.Equal(len(unsignedMsgs), newSigner.calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(backendIntf.ReSignAll(ctx), context.Canceled)
+}
+
+func TestSubscribeNewSignatures(t *testing.T) {
+	// Closing the backend also stops the goroutines of subscriptions whose context is never done.
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+
+	for _, signingConcurrency := range []int{0, 2} {
+		backend := NewBackend(memdb.New(), BackendConfig{
+			NetworkID:          networkID,
+			SourceChainID:      sourceChainID,
+			Signer:             warpSigner,
+			CacheSize:          500,
+			SigningConcurrency: signingConcurrency,
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signatures, err := backend.SubscribeNewSignatures(ctx)
+		require.NoError(err)
+		closedSignatures, err := backend.SubscribeNewSignatures(context.Background())
+		require.NoError(err)
+
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+		require.NoError(err)
+		require.NoError(backend.AddMessage(unsignedMsg))
+		// Adding a message that is already tracked does not sign it again.
+		require.NoError(backend.AddMessage(unsignedMsg))
+
+		expectedSig, err := warpSigner.Sign(unsignedMsg)
+		require.NoError(err)
+		signedMsg := <-signatures
+		require.Equal(unsignedMsg.ID(), signedMsg.Message.ID())
+		require.Equal(expectedSig, signedMsg.Signature[:])
+
+		// The subscription is closed once its context is done.
+		cancel()
+		for range signatures {
+			require.FailNow("unexpected signature after the subscription was cancelled")
+		}
+
+		// Closing the backend closes the remaining subscriptions.
+		require.Equal(unsignedMsg.ID(), (<-closedSignatures).Message.ID())
+		backend.Close()
+		_, ok := <-closedSignatures
+		require.False(ok)
+		_, err = backend.SubscribeNewSignatures(context.Background())
+		require.ErrorIs(err, errBackendClosed)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend := NewBackend(db, BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            warpSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(sk),
+		PersistSignatures: true,
+	})
+	require.NoError(backend.HealthCheck(context.Background()))
+
+	// The health check message is not stored.
+	messageIDs, err := backend.GetMessageIDs(context.Background())
+	require.NoError(err)
+	require.Empty(messageIDs)
+	it := db.NewIterator()
+	require.False(it.Next())
+	it.Release()
+
+	// A signer that does not match the public key of the backend is unhealthy.
+	otherSk, err := bls.NewSecretKey()
+	require.NoError(err)
+	backend = NewBackend(db, BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            warpSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(otherSk),
+		PersistSignatures: true,
+	})
+	require.ErrorIs(backend.HealthCheck(context.Background()), errHealthCheckSignature)
+
+	// A closed database is unhealthy.
+	require.NoError(db.Close())
+	require.ErrorIs(backend.HealthCheck(context.Background()), database.ErrClosed)
+}
+
+// failingSigner wraps a Signer and fails the first [failures] calls to Sign.
+type failingSigner struct {
+	avalancheWarp.Signer
+	failures int
+	calls    int
+}
+
+var errSignerUnavailable = errors.New("signer unavailable")
+
+func (s *failingSigner) Sign(msg *avalancheWarp.UnsignedMessage) ([]byte, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, errSignerUnavailable
+	}
+	return s.Signer.Sign(msg)
+}
+
+func TestAddMessageSignRetry(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(t, err)
+	messageID := unsignedMsg.ID()
+
+	t.Run("transient failure", func(t *testing.T) {
+		require := require.New(t)
+		warpSigner := &failingSigner{Signer: avalancheWarp.NewSigner(sk, networkID, sourceChainID), failures: 2}
+		backend := NewBackend(memdb.New(), BackendConfig{
+			NetworkID:      networkID,
+			SourceChainID:  sourceChainID,
+			Signer:         warpSigner,
+			CacheSize:      500,
+			SignAttempts:   3,
+			SignRetryDelay: time.Millisecond,
+		})
+
+		require.NoError(backend.AddMessage(unsignedMsg))
+		require.Equal(3, warpSigner.calls)
+		signature, err := backend.GetMessageSignature(context.Background(), messageID)
+		require.NoError(err)
+		expectedSig, err := avalancheWarp.NewSigner(sk, networkID, sourceChainID).Sign(unsignedMsg)
+		require.NoError(err)
+		require.Equal(expectedSig, signature[:])
+		require.Equal(3, warpSigner.calls)
+	})
+
+	t.Run("persistent failure rolls back", func(t *testing.T) {
+		require := require.New(t)
+		db := memdb.New()
+		warpSigner := &failingSigner{Signer: avalancheWarp.NewSigner(sk, networkID, sourceChainID), failures: 3}
+		backend := NewBackend(db, BackendConfig{
+			NetworkID:      networkID,
+			SourceChainID:  sourceChainID,
+			Signer:         warpSigner,
+			CacheSize:      500,
+			SignAttempts:   3,
+			SignRetryDelay: time.Millisecond,
+		})
+
+		require.ErrorIs(backend.AddMessage(unsignedMsg), errSignerUnavailable)
+		require.Equal(3, warpSigner.calls)
+		has, err := backend.HasMessage(context.Background(), messageID)
+		require.NoError(err)
+		require.False(has)
+		it := db.NewIterator()
+		require.False(it.Next())
+		it.Release()
+
+		// Once the signer recovers, adding the message again signs it.
+		require.NoError(backend.AddMessage(unsignedMsg))
+		require.Equal(4, warpSigner.calls)
+		_, err = backend.GetMessageSignature(context.Background(), messageID)
+		require.NoError(err)
+	})
+
+	t.Run("asynchronous persistent failure keeps the message", func(t *testing.T) {
+		require := require.New(t)
+		warpSigner := &failingSigner{Signer: avalancheWarp.NewSigner(sk, networkID, sourceChainID), failures: 3}
+		backend := NewBackend(memdb.New(), BackendConfig{
+			NetworkID:          networkID,
+			SourceChainID:      sourceChainID,
+			Signer:             warpSigner,
+			CacheSize:          500,
+			SigningConcurrency: 1,
+			SignAttempts:       3,
+			SignRetryDelay:     time.Millisecond,
+		})
+		defer backend.Close()
+
+		require.NoError(backend.AddMessage(unsignedMsg))
+
+		// The background signing fails, but the message is kept and signed on demand once the signer recovers.
+		signature, err := backend.GetMessageSignature(context.Background(), messageID)
+		require.NoError(err)
+		require.Equal(4, warpSigner.calls)
+		expectedSig, err := avalancheWarp.NewSigner(sk, networkID, sourceChainID).Sign(unsignedMsg)
+		require.NoError(err)
+		require.Equal(expectedSig, signature[:])
+		has, err := backend.HasMessage(context.Background(), messageID)
+		require.NoError(err)
+		require.True(has)
+	})
+
+	t.Run("no retry by default", func(t *testing.T) {
+		require := require.New(t)
+		warpSigner := &failingSigner{Signer: avalancheWarp.NewSigner(sk, networkID, sourceChainID), failures: 1}
+		backend := NewBackend(memdb.New(), BackendConfig{
+			NetworkID:     networkID,
+			SourceChainID: sourceChainID,
+			Signer:        warpSigner,
+			CacheSize:     500,
+		})
+
+		require.ErrorIs(backend.AddMessage(unsignedMsg), errSignerUnavailable)
+		require.Equal(1, warpSigner.calls)
+	})
+}
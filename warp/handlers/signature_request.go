@@ -5,6 +5,8 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -15,6 +17,15 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// maxRawMessageSignatureRequestSize is the size of a raw message signature request, which is a single message ID.
+const maxRawMessageSignatureRequestSize = ids.IDLen
+
+var (
+	ErrRequestTooLarge   = errors.New("signature request too large")
+	ErrInvalidRequest    = errors.New("invalid signature request")
+	ErrSignatureNotFound = errors.New("signature not found")
+)
+
 // SignatureRequestHandler serves warp signature requests. It is a peer.RequestHandler for message.MessageSignatureRequest.
 type SignatureRequestHandler struct {
 	backend warp.Backend
@@ -44,7 +55,7 @@ func (s *SignatureRequestHandler) OnMessageSignatureRequest(ctx context.Context,
 		s.stats.UpdateMessageSignatureRequestTime(time.Since(startTime))
 	}()
 
-	signature, err := s.backend.GetMessageSignature(signatureRequest.MessageID)
+	signature, err := s.backend.GetMessageSignature(ctx, signatureRequest.MessageID)
 	if err != nil {
 		log.Debug("Unknown warp signature requested", "messageID", signatureRequest.MessageID)
 		s.stats.IncMessageSignatureMiss()
@@ -72,7 +83,7 @@ func (s *SignatureRequestHandler) OnBlockSignatureRequest(ctx context.Context, n
 		s.stats.UpdateBlockSignatureRequestTime(time.Since(startTime))
 	}()
 
-	signature, err := s.backend.GetBlockSignature(request.BlockID)
+	signature, err := s.backend.GetBlockSignature(ctx, request.BlockID)
 	if err != nil {
 		log.Debug("Unknown warp signature requested", "blockID", request.BlockID)
 		s.stats.IncBlockSignatureMiss()
@@ -91,6 +102,37 @@ func (s *SignatureRequestHandler) OnBlockSignatureRequest(ctx context.Context, n
 	return responseBytes, nil
 }
 
+// HandleRawMessageSignatureRequest parses [requestBytes] as a message ID and returns the marshalled
+// message.SignatureResponse for it. Unlike OnMessageSignatureRequest, failures are reported as errors
+// wrapping ErrRequestTooLarge, ErrInvalidRequest or ErrSignatureNotFound instead of an empty response.
+func (s *SignatureRequestHandler) HandleRawMessageSignatureRequest(ctx context.Context, requestBytes []byte) ([]byte, error) {
+	if len(requestBytes) > maxRawMessageSignatureRequestSize {
+		return nil, fmt.Errorf("%w: %d bytes exceeds maximum of %d", ErrRequestTooLarge, len(requestBytes), maxRawMessageSignatureRequestSize)
+	}
+	messageID, err := ids.ToID(requestBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	startTime := time.Now()
+	s.stats.IncMessageSignatureRequest()
+
+	// Always report signature request time
+	defer func() {
+		s.stats.UpdateMessageSignatureRequestTime(time.Since(startTime))
+	}()
+
+	signature, err := s.backend.GetMessageSignature(ctx, messageID)
+	if err != nil {
+		s.stats.IncMessageSignatureMiss()
+		return nil, fmt.Errorf("%w for message %s: %w", ErrSignatureNotFound, messageID, err)
+	}
+	s.stats.IncMessageSignatureHit()
+
+	response := message.SignatureResponse{Signature: signature}
+	return s.codec.Marshal(message.Version, &response)
+}
+
 type NoopSignatureRequestHandler struct{}
 
 func (s *NoopSignatureRequestHandler) OnMessageSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest message.MessageSignatureRequest) ([]byte, error) {
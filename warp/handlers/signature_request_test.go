@@ -20,7 +20,9 @@ import (
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	"github.com/ava-labs/subnet-evm/warp"
+	warptestutils "github.com/ava-labs/subnet-evm/warp/testutils"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestMessageSignatureHandler(t *testing.T) {
@@ -30,14 +32,20 @@ func TestMessageSignatureHandler(t *testing.T) {
 	require.NoError(t, err)
 
 	warpSigner := avalancheWarp.NewSigner(blsSecretKey, snowCtx.NetworkID, snowCtx.ChainID)
-	backend := warp.NewBackend(snowCtx.NetworkID, snowCtx.ChainID, warpSigner, &block.TestVM{TestVM: common.TestVM{T: t}}, database, 100)
+	backend := warp.NewBackend(database, warp.BackendConfig{
+		NetworkID:     snowCtx.NetworkID,
+		SourceChainID: snowCtx.ChainID,
+		Signer:        warpSigner,
+		BlockClient:   &block.TestVM{TestVM: common.TestVM{T: t}},
+		CacheSize:     100,
+	})
 
 	msg, err := avalancheWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, []byte("test"))
 	require.NoError(t, err)
 
 	messageID := msg.ID()
 	require.NoError(t, backend.AddMessage(msg))
-	signature, err := backend.GetMessageSignature(messageID)
+	signature, err := backend.GetMessageSignature(context.Background(), messageID)
 	require.NoError(t, err)
 	unknownMessageID := ids.GenerateTestID()
 
@@ -130,16 +138,15 @@ func TestBlockSignatureHandler(t *testing.T) {
 			return nil, errors.New("invalid blockID")
 		},
 	}
-	backend := warp.NewBackend(
-		snowCtx.NetworkID,
-		snowCtx.ChainID,
-		warpSigner,
-		testVM,
-		database,
-		100,
-	)
-
-	signature, err := backend.GetBlockSignature(blkID)
+	backend := warp.NewBackend(database, warp.BackendConfig{
+		NetworkID:     snowCtx.NetworkID,
+		SourceChainID: snowCtx.ChainID,
+		Signer:        warpSigner,
+		BlockClient:   testVM,
+		CacheSize:     100,
+	})
+
+	signature, err := backend.GetBlockSignature(context.Background(), blkID)
 	require.NoError(t, err)
 	unknownMessageID := ids.GenerateTestID()
 
@@ -209,3 +216,87 @@ func TestBlockSignatureHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestRawMessageSignatureHandler(t *testing.T) {
+	database := memdb.New()
+	snowCtx := snow.DefaultContextTest()
+	blsSecretKey, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	warpSigner := avalancheWarp.NewSigner(blsSecretKey, snowCtx.NetworkID, snowCtx.ChainID)
+	backend := warp.NewBackend(database, warp.BackendConfig{
+		NetworkID:     snowCtx.NetworkID,
+		SourceChainID: snowCtx.ChainID,
+		Signer:        warpSigner,
+		BlockClient:   &block.TestVM{TestVM: common.TestVM{T: t}},
+		CacheSize:     100,
+	})
+
+	msg, err := avalancheWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, []byte("test"))
+	require.NoError(t, err)
+	messageID := msg.ID()
+	require.NoError(t, backend.AddMessage(msg))
+	signature, err := backend.GetMessageSignature(context.Background(), messageID)
+	require.NoError(t, err)
+	unknownMessageID := ids.GenerateTestID()
+
+	tests := map[string]struct {
+		requestBytes      []byte
+		expectedSignature []byte
+		expectedErr       error
+	}{
+		"known message": {
+			requestBytes:      messageID[:],
+			expectedSignature: signature[:],
+		},
+		"unknown message": {
+			requestBytes: unknownMessageID[:],
+			expectedErr:  ErrSignatureNotFound,
+		},
+		"short request": {
+			requestBytes: messageID[:ids.IDLen-1],
+			expectedErr:  ErrInvalidRequest,
+		},
+		"oversized request": {
+			requestBytes: append(messageID[:], 0),
+			expectedErr:  ErrRequestTooLarge,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := NewSignatureRequestHandler(backend, message.Codec)
+
+			responseBytes, err := handler.HandleRawMessageSignatureRequest(context.Background(), test.requestBytes)
+			require.ErrorIs(t, err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			var response message.SignatureResponse
+			_, err = message.Codec.Unmarshal(responseBytes, &response)
+			require.NoError(t, err, "error unmarshalling SignatureResponse")
+			require.Equal(t, test.expectedSignature, response.Signature[:])
+		})
+	}
+}
+
+func TestMessageSignatureHandlerSigningFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := warptestutils.NewMockBackend(ctrl)
+
+	messageID := ids.GenerateTestID()
+	backend.EXPECT().GetMessageSignature(gomock.Any(), messageID).Return([bls.SignatureLen]byte{}, errors.New("signing failed"))
+
+	handler := NewSignatureRequestHandler(backend, message.Codec)
+	handler.stats.Clear()
+
+	responseBytes, err := handler.OnMessageSignatureRequest(context.Background(), ids.GenerateTestNodeID(), 1, message.MessageSignatureRequest{MessageID: messageID})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, handler.stats.messageSignatureMiss.Count())
+
+	var response message.SignatureResponse
+	_, err = message.Codec.Unmarshal(responseBytes, &response)
+	require.NoError(t, err, "error unmarshalling SignatureResponse")
+	require.Equal(t, [bls.SignatureLen]byte{}, response.Signature)
+}
@@ -0,0 +1,48 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/subnet-evm/metrics"
+)
+
+type backendStats struct {
+	// message signature metrics
+	messageSignatureCacheHit  metrics.Counter
+	messageSignatureCacheMiss metrics.Counter
+	messageDBRead             metrics.Counter
+	messageSignatureDBHit     metrics.Counter
+	messageSign               metrics.Counter
+	messageMissCacheHit       metrics.Counter
+	messageRejected           metrics.Counter
+	// block signature metrics
+	blockSignatureCacheHit  metrics.Counter
+	blockSignatureCacheMiss metrics.Counter
+}
+
+// newBackendStats registers the backend metrics in [registry].
+// If [registry] is nil, the default registry is used.
+func newBackendStats(registry metrics.Registry) *backendStats {
+	return &backendStats{
+		messageSignatureCacheHit:  metrics.GetOrRegisterCounter("warp_backend_message_signature_cache_hit", registry),
+		messageSignatureCacheMiss: metrics.GetOrRegisterCounter("warp_backend_message_signature_cache_miss", registry),
+		messageDBRead:             metrics.GetOrRegisterCounter("warp_backend_message_db_read", registry),
+		messageSignatureDBHit:     metrics.GetOrRegisterCounter("warp_backend_message_signature_db_hit", registry),
+		messageSign:               metrics.GetOrRegisterCounter("warp_backend_message_sign", registry),
+		messageMissCacheHit:       metrics.GetOrRegisterCounter("warp_backend_message_miss_cache_hit", registry),
+		messageRejected:           metrics.GetOrRegisterCounter("warp_backend_message_rejected", registry),
+		blockSignatureCacheHit:    metrics.GetOrRegisterCounter("warp_backend_block_signature_cache_hit", registry),
+		blockSignatureCacheMiss:   metrics.GetOrRegisterCounter("warp_backend_block_signature_cache_miss", registry),
+	}
+}
+
+func (s *backendStats) IncMessageSignatureCacheHit()  { s.messageSignatureCacheHit.Inc(1) }
+func (s *backendStats) IncMessageSignatureCacheMiss() { s.messageSignatureCacheMiss.Inc(1) }
+func (s *backendStats) IncMessageDBRead()             { s.messageDBRead.Inc(1) }
+func (s *backendStats) IncMessageSignatureDBHit()     { s.messageSignatureDBHit.Inc(1) }
+func (s *backendStats) IncMessageSign()               { s.messageSign.Inc(1) }
+func (s *backendStats) IncMessageMissCacheHit()       { s.messageMissCacheHit.Inc(1) }
+func (s *backendStats) IncMessageRejected()           { s.messageRejected.Inc(1) }
+func (s *backendStats) IncBlockSignatureCacheHit()    { s.blockSignatureCacheHit.Inc(1) }
+func (s *backendStats) IncBlockSignatureCacheMiss()   { s.blockSignatureCacheMiss.Inc(1) }
@@ -0,0 +1,57 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var _ Storage = (*databaseStorage)(nil)
+
+// Storage is the key-value store the warp backend keeps messages, their timestamps and their signatures in.
+// Get must return database.ErrNotFound for a missing key.
+type Storage interface {
+	database.KeyValueReaderWriterDeleter
+	health.Checker
+
+	// DeleteAll deletes [keys] atomically, so either all or none of them are deleted.
+	DeleteAll(keys [][]byte) error
+	// Iterate calls [fn] with each key and value in the storage in ascending key order, stopping at
+	// the first error returned by [fn]. The key and value are only valid until [fn] returns, and
+	// [fn] must not modify the storage.
+	Iterate(fn func(key []byte, value []byte) error) error
+}
+
+// databaseStorage implements Storage with a database.Database.
+type databaseStorage struct {
+	database.Database
+}
+
+// NewDatabaseStorage returns a Storage that keeps the warp backend data in [db].
+func NewDatabaseStorage(db database.Database) Storage {
+	return &databaseStorage{Database: db}
+}
+
+func (s *databaseStorage) DeleteAll(keys [][]byte) error {
+	batch := s.NewBatch()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func (s *databaseStorage) Iterate(fn func(key []byte, value []byte) error) error {
+	it := s.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
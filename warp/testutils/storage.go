@@ -0,0 +1,102 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/subnet-evm/warp"
+)
+
+var _ warp.Storage = (*MemoryStorage)(nil)
+
+// MemoryStorage is a warp.Storage that keeps the warp backend data in a map, for use in tests.
+// It is safe for concurrent use.
+type MemoryStorage struct {
+	lock sync.RWMutex
+	data map[string][]byte
+}
+
+// NewMemoryStorage returns an empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{data: make(map[string][]byte)}
+}
+
+func (s *MemoryStorage) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, ok := s.data[string(key)]
+	return ok, nil
+}
+
+func (s *MemoryStorage) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	value, ok := s.data[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return bytes.Clone(value), nil
+}
+
+func (s *MemoryStorage) Put(key []byte, value []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.data[string(key)] = bytes.Clone(value)
+	return nil
+}
+
+func (s *MemoryStorage) Delete(key []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.data, string(key))
+	return nil
+}
+
+func (s *MemoryStorage) DeleteAll(keys [][]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, key := range keys {
+		delete(s.data, string(key))
+	}
+	return nil
+}
+
+func (s *MemoryStorage) Iterate(fn func(key []byte, value []byte) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	keys := make([]string, 0, len(s.data))
+	for key := range s.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn([]byte(key), s.data[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) HealthCheck(context.Context) (interface{}, error) {
+	return nil, nil
+}
+
+// Len returns the number of keys in the storage.
+func (s *MemoryStorage) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.data)
+}
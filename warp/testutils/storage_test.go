@@ -0,0 +1,103 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/warp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	require := require.New(t)
+	storage := NewMemoryStorage()
+
+	_, err := storage.Get([]byte("a"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	value := []byte("value")
+	require.NoError(storage.Put([]byte("b"), value))
+	require.NoError(storage.Put([]byte("a"), value))
+	require.NoError(storage.Put([]byte("c"), value))
+	// The storage keeps its own copy of the value.
+	value[0] = 'V'
+	got, err := storage.Get([]byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("value"), got)
+
+	var keys []string
+	require.NoError(storage.Iterate(func(key []byte, _ []byte) error {
+		keys = append(keys, string(key))
+		return nil
+	}))
+	require.Equal([]string{"a", "b", "c"}, keys)
+
+	require.NoError(storage.DeleteAll([][]byte{[]byte("a"), []byte("c")}))
+	require.NoError(storage.Delete([]byte("b")))
+	require.Zero(storage.Len())
+}
+
+func TestBackendWithMemoryStorage(t *testing.T) {
+	require := require.New(t)
+	storage := NewMemoryStorage()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	networkID := uint32(54321)
+	sourceChainID := ids.GenerateTestID()
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend := warp.NewBackendWithStorage(storage, warp.BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            warpSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(sk),
+		PersistSignatures: true,
+	})
+
+	messageIDs := make([]ids.ID, 0, 3)
+	for _, payload := range []string{"a", "b", "c"} {
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, []byte(payload))
+		require.NoError(err)
+		require.NoError(backend.AddMessage(unsignedMsg))
+		messageIDs = append(messageIDs, unsignedMsg.ID())
+	}
+	// Each message is stored with its timestamp and signature.
+	require.Equal(9, storage.Len())
+
+	storedIDs, err := backend.GetMessageIDs(context.Background())
+	require.NoError(err)
+	require.ElementsMatch(messageIDs, storedIDs)
+
+	require.NoError(backend.DeleteMessage(context.Background(), messageIDs[0]))
+	require.Equal(6, storage.Len())
+
+	// Messages with a cached signature are not pruned, so prune with a new backend.
+	backend = warp.NewBackendWithStorage(storage, warp.BackendConfig{
+		NetworkID:         networkID,
+		SourceChainID:     sourceChainID,
+		Signer:            warpSigner,
+		CacheSize:         500,
+		PublicKey:         bls.PublicFromSecretKey(sk),
+		PersistSignatures: true,
+	})
+	pruned, err := backend.Prune(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(err)
+	require.Zero(pruned)
+	pruned, err = backend.Prune(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(err)
+	require.Equal(2, pruned)
+	require.Zero(storage.Len())
+
+	require.NoError(storage.Put([]byte("other"), []byte("value")))
+	require.NoError(backend.Clear())
+	require.Zero(storage.Len())
+}
@@ -105,6 +105,14 @@ func (c *Config) Equal(s precompileconfig.Config) bool {
 	return equals && c.QuorumNumerator == other.QuorumNumerator
 }
 
+// Copy returns a deep copy of [c].
+func (c *Config) Copy() precompileconfig.Config {
+	return &Config{
+		Upgrade:         c.Upgrade.Copy(),
+		QuorumNumerator: c.QuorumNumerator,
+	}
+}
+
 func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash common.Hash, blockNumber uint64, txHash common.Hash, logIndex int, topics []common.Hash, logData []byte) error {
 	unsignedMessage, err := UnpackSendWarpEventDataToMessage(logData)
 	if err != nil {
@@ -86,3 +86,9 @@ func TestEqualWarpConfig(t *testing.T) {
 	}
 	testutils.RunEqualTests(t, tests)
 }
+
+func TestConfigRoundTrip(t *testing.T) {
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewConfig(utils.NewUint64(3), params.WarpQuorumNumeratorMinimum+1))
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewDefaultConfig(utils.NewUint64(3)))
+	testutils.AssertConfigRoundTrip(t, Module.Configurator, NewDisableConfig(utils.NewUint64(4)))
+}
@@ -79,7 +79,7 @@ func TestGetBlockchainID(t *testing.T) {
 		},
 	}
 
-	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests, false)
 }
 
 func TestSendWarpMessage(t *testing.T) {
@@ -102,6 +102,12 @@ func TestSendWarpMessage(t *testing.T) {
 		sendWarpMessageAddressedPayload.Bytes(),
 	)
 	require.NoError(t, err)
+	sendWarpMessageTopics, sendWarpMessageData, err := PackSendWarpMessageEvent(
+		callerAddr,
+		common.Hash(unsignedWarpMessage.ID()),
+		unsignedWarpMessage.Bytes(),
+	)
+	require.NoError(t, err)
 
 	tests := map[string]testutils.PrecompileTest{
 		"send warp message readOnly": {
@@ -146,6 +152,12 @@ func TestSendWarpMessage(t *testing.T) {
 				}
 				return bytes
 			}(),
+			ExpectedLogs: []testutils.Log{
+				{
+					Topics: sendWarpMessageTopics,
+					Data:   sendWarpMessageData,
+				},
+			},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
 				logsData := state.GetLogData()
 				require.Len(t, logsData, 1)
@@ -163,7 +175,21 @@ func TestSendWarpMessage(t *testing.T) {
 		},
 	}
 
-	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests, true)
+}
+
+func TestSendWarpMessageGasScaling(t *testing.T) {
+	test := testutils.PrecompileTest{
+		Caller:      common.HexToAddress("0x0123"),
+		SuppliedGas: math.MaxUint64 / 2,
+	}
+	inputFn := func(t testing.TB, size int) []byte {
+		input, err := PackSendWarpMessage(utils.RandomBytes(size))
+		require.NoError(t, err)
+		return input
+	}
+	costPerByte := testutils.RunGasScalingTest(t, Module, state.NewTestStateDB, test, inputFn, []int{0, 32, 256, 1024, 4096}, 0.01)
+	require.InDelta(t, SendWarpMessageGasCostPerByte, costPerByte, 0.01)
 }
 
 func TestGetVerifiedWarpMessage(t *testing.T) {
@@ -448,7 +474,7 @@ func TestGetVerifiedWarpMessage(t *testing.T) {
 		},
 	}
 
-	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests, false)
 }
 
 func TestGetVerifiedWarpBlockHash(t *testing.T) {
@@ -726,7 +752,7 @@ func TestGetVerifiedWarpBlockHash(t *testing.T) {
 		},
 	}
 
-	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests, false)
 }
 
 func TestPackEvents(t *testing.T) {